@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
@@ -11,10 +12,21 @@ type Visitor interface {
 	VisitBoolean(node BooleanLiteral) interface{}
 	VisitNil(node NilLiteral) interface{}
 	VisitNum(node NumLiteral) interface{}
+	VisitBigInt(node BigIntLiteral) interface{}
+	VisitDecimal(node DecimalLiteral) interface{}
 	VisitString(node StringLiteral) interface{}
 	VisitGroupedExpr(node GroupedExpr) interface{}
 	VisitPrefixExpr(node PrefixExpr) interface{}
 	VisitInfixExpr(node InfixExpr) interface{}
+	VisitIdentifier(node Identifier) interface{}
+	VisitCallExpr(node CallExpr) interface{}
+	VisitFunctionLiteral(node FunctionLiteral) interface{}
+	VisitInterpolationExpr(node InterpolationExpr) interface{}
+	VisitCompoundAssignExpr(node CompoundAssignExpr) interface{}
+	VisitUpdateExpr(node UpdateExpr) interface{}
+	VisitMatchExpr(node MatchExpr) interface{}
+	VisitGetExpr(node GetExpr) interface{}
+	VisitSpawnExpr(node SpawnExpr) interface{}
 }
 
 type Node interface {
@@ -57,6 +69,24 @@ func (n NumLiteral) String() string {
 }
 func (n NumLiteral) Accept(visitor Visitor) interface{} { return visitor.VisitNum(n) }
 
+type BigIntLiteral struct {
+	Token lexer.Token
+	Value *big.Int
+}
+
+func (n BigIntLiteral) Type() string                       { return "BIGINT" }
+func (n BigIntLiteral) String() string                     { return n.Value.String() + "n" }
+func (n BigIntLiteral) Accept(visitor Visitor) interface{} { return visitor.VisitBigInt(n) }
+
+type DecimalLiteral struct {
+	Token lexer.Token
+	Value *big.Rat
+}
+
+func (n DecimalLiteral) Type() string                       { return "DECIMAL" }
+func (n DecimalLiteral) String() string                     { return n.Token.Lexeme }
+func (n DecimalLiteral) Accept(visitor Visitor) interface{} { return visitor.VisitDecimal(n) }
+
 type StringLiteral struct {
 	Token lexer.Token
 	Value string
@@ -66,6 +96,17 @@ func (n StringLiteral) Type() string                       { return "STRING" }
 func (n StringLiteral) String() string                     { return fmt.Sprintf("%s", n.Value) }
 func (n StringLiteral) Accept(visitor Visitor) interface{} { return visitor.VisitString(n) }
 
+type InterpolationExpr struct {
+	Token lexer.Token
+	Parts []Node
+}
+
+func (n InterpolationExpr) Type() string   { return "INTERPOLATION_EXPR" }
+func (n InterpolationExpr) String() string { return parenthesize("interpolate", n.Parts...) }
+func (n InterpolationExpr) Accept(visitor Visitor) interface{} {
+	return visitor.VisitInterpolationExpr(n)
+}
+
 type GroupedExpr struct {
 	Token lexer.Token
 	Value Node
@@ -96,6 +137,267 @@ func (n InfixExpr) Type() string                       { return "INFIX_EXPR" }
 func (n InfixExpr) String() string                     { return parenthesize(n.Op, n.Left, n.Right) }
 func (n InfixExpr) Accept(visitor Visitor) interface{} { return visitor.VisitInfixExpr(n) }
 
+// CompoundAssignExpr is an assignment combined with an arithmetic operator,
+// e.g. 'a += 1'. Op holds the operator without the trailing '='.
+type CompoundAssignExpr struct {
+	Token  lexer.Token
+	Target Node
+	Op     string
+	Value  Node
+}
+
+func (n CompoundAssignExpr) Type() string { return "COMPOUND_ASSIGN_EXPR" }
+func (n CompoundAssignExpr) String() string {
+	return parenthesize(n.Token.Lexeme, n.Target, n.Value)
+}
+func (n CompoundAssignExpr) Accept(visitor Visitor) interface{} {
+	return visitor.VisitCompoundAssignExpr(n)
+}
+
+// UpdateExpr is an increment or decrement, either prefix ('++a') or postfix
+// ('a++').
+type UpdateExpr struct {
+	Token  lexer.Token
+	Target Node
+	Op     string
+	Prefix bool
+}
+
+func (n UpdateExpr) Type() string { return "UPDATE_EXPR" }
+func (n UpdateExpr) String() string {
+	if n.Prefix {
+		return parenthesize(n.Op, n.Target)
+	}
+
+	return "(" + n.Target.String() + " " + n.Op + ")"
+}
+func (n UpdateExpr) Accept(visitor Visitor) interface{} { return visitor.VisitUpdateExpr(n) }
+
+type Identifier struct {
+	Token lexer.Token
+	Value string
+}
+
+func (n Identifier) Type() string                       { return "IDENTIFIER" }
+func (n Identifier) String() string                     { return n.Value }
+func (n Identifier) Accept(visitor Visitor) interface{} { return visitor.VisitIdentifier(n) }
+
+type CallExpr struct {
+	Token     lexer.Token // '('
+	Callee    Node
+	Args      []Node
+	NamedArgs []NamedArg
+}
+
+func (n CallExpr) Type() string { return "CALL_EXPR" }
+func (n CallExpr) String() string {
+	call := parenthesize("call", append([]Node{n.Callee}, n.Args...)...)
+	if len(n.NamedArgs) == 0 {
+		return call
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimSuffix(call, ")"))
+
+	for _, arg := range n.NamedArgs {
+		sb.WriteString(" ")
+		sb.WriteString(arg.String())
+	}
+
+	sb.WriteString(")")
+
+	return sb.String()
+}
+
+// NamedArg is an argument passed by parameter name, e.g. 'b: 3' in
+// 'f(1, b: 3)'. It is only valid inside a CallExpr.
+type NamedArg struct {
+	Name  Identifier
+	Value Node
+}
+
+func (n NamedArg) String() string                     { return n.Name.String() + ": " + n.Value.String() }
+func (n CallExpr) Accept(visitor Visitor) interface{} { return visitor.VisitCallExpr(n) }
+
+type GetExpr struct {
+	Token  lexer.Token // '.'
+	Object Node
+	Name   Identifier
+}
+
+func (n GetExpr) Type() string                       { return "GET_EXPR" }
+func (n GetExpr) String() string                     { return parenthesize(".", n.Object, n.Name) }
+func (n GetExpr) Accept(visitor Visitor) interface{} { return visitor.VisitGetExpr(n) }
+
+// SpawnExpr runs a function call on a new goroutine.
+type SpawnExpr struct {
+	Token lexer.Token
+	Call  CallExpr
+}
+
+func (n SpawnExpr) Type() string                       { return "SPAWN_EXPR" }
+func (n SpawnExpr) String() string                     { return parenthesize("spawn", n.Call) }
+func (n SpawnExpr) Accept(visitor Visitor) interface{} { return visitor.VisitSpawnExpr(n) }
+
+type FunctionLiteral struct {
+	Token      lexer.Token // '('
+	Params     []Param
+	ReturnType *TypeAnnotation
+	Body       Node
+}
+
+func (n FunctionLiteral) Type() string { return "FUNCTION_LITERAL" }
+func (n FunctionLiteral) String() string {
+	params := make([]string, 0, len(n.Params))
+	for _, param := range n.Params {
+		params = append(params, param.String())
+	}
+
+	if n.ReturnType != nil {
+		return fmt.Sprintf("(fun (%s):%s %s)", strings.Join(params, " "), n.ReturnType, n.Body.String())
+	}
+
+	return fmt.Sprintf("(fun (%s) %s)", strings.Join(params, " "), n.Body.String())
+}
+func (n FunctionLiteral) Accept(visitor Visitor) interface{} { return visitor.VisitFunctionLiteral(n) }
+
+type MatchExpr struct {
+	Token   lexer.Token
+	Subject Node
+	Arms    []MatchArm
+}
+
+func (n MatchExpr) Type() string { return "MATCH_EXPR" }
+func (n MatchExpr) String() string {
+	var sb strings.Builder
+	sb.WriteString("(match ")
+	sb.WriteString(n.Subject.String())
+
+	for _, arm := range n.Arms {
+		sb.WriteString(" ")
+		sb.WriteString(arm.String())
+	}
+
+	sb.WriteString(")")
+
+	return sb.String()
+}
+func (n MatchExpr) Accept(visitor Visitor) interface{} { return visitor.VisitMatchExpr(n) }
+
+// MatchArm is a single 'pattern [if guard] => body' arm of a match
+// expression. Guard is nil for arms without one.
+type MatchArm struct {
+	Token   lexer.Token
+	Pattern Pattern
+	Guard   Node
+	Body    Node
+}
+
+func (a MatchArm) String() string {
+	if a.Guard != nil {
+		return fmt.Sprintf("(%s if %s => %s)", a.Pattern, a.Guard, a.Body)
+	}
+
+	return fmt.Sprintf("(%s => %s)", a.Pattern, a.Body)
+}
+
+// Pattern is the left-hand side of a match arm.
+type Pattern interface {
+	String() string
+}
+
+// LiteralPattern matches values equal to a literal.
+type LiteralPattern struct {
+	Value Node
+}
+
+func (p LiteralPattern) String() string { return p.Value.String() }
+
+// BindingPattern matches any value and binds it to a name in the arm.
+type BindingPattern struct {
+	Name Identifier
+}
+
+func (p BindingPattern) String() string { return p.Name.String() }
+
+// WildcardPattern ('_') matches any value without binding it.
+type WildcardPattern struct {
+	Token lexer.Token
+}
+
+func (p WildcardPattern) String() string { return "_" }
+
+// AlternativePattern ('1 | 2') matches if any of its alternatives does.
+type AlternativePattern struct {
+	Alternatives []Pattern
+}
+
+func (p AlternativePattern) String() string {
+	alts := make([]string, 0, len(p.Alternatives))
+	for _, alt := range p.Alternatives {
+		alts = append(alts, alt.String())
+	}
+
+	return strings.Join(alts, " | ")
+}
+
+// ListPattern ('[a, _, ...rest]') matches lists whose elements match its
+// elements in order. Without Rest the lengths must be equal; otherwise Rest
+// matches a list of the remaining elements.
+type ListPattern struct {
+	Token    lexer.Token
+	Elements []Pattern
+	Rest     Pattern // BindingPattern or WildcardPattern, nil if absent
+}
+
+func (p ListPattern) String() string {
+	elems := make([]string, 0, len(p.Elements)+1)
+	for _, elem := range p.Elements {
+		elems = append(elems, elem.String())
+	}
+	if p.Rest != nil {
+		elems = append(elems, "..."+p.Rest.String())
+	}
+
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// Param is a function parameter. It can have a type annotation and a default
+// value, or collect the remaining arguments if Rest is set.
+type Param struct {
+	Name    Identifier
+	Type    *TypeAnnotation
+	Default Node
+	Rest    bool
+}
+
+func (p Param) String() string {
+	s := p.Name.String()
+	if p.Rest {
+		s = "..." + s
+	}
+
+	if p.Type != nil {
+		s += ":" + p.Type.String()
+	}
+
+	if p.Default != nil {
+		s += "=" + p.Default.String()
+	}
+
+	return s
+}
+
+// TypeAnnotation names the expected type of a parameter or a return value.
+// Annotations are only used by the static type checker and are ignored at
+// runtime.
+type TypeAnnotation struct {
+	Token lexer.Token
+	Name  string
+}
+
+func (t TypeAnnotation) String() string { return t.Name }
+
 func parenthesize(op string, expr ...Node) string {
 	var sb strings.Builder
 	sb.WriteString("(")
@@ -0,0 +1,209 @@
+package eval
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// decimalPrecision is the number of fractional digits printed for decimals
+// that have no finite decimal expansion, e.g. 1d / 3d.
+const decimalPrecision = 28
+
+var errDivisionByZero = errors.New("Division by zero.")
+
+type BigIntObject struct {
+	Value *big.Int
+}
+
+func (o BigIntObject) Type() string {
+	return "BIGINT_OBJ"
+}
+func (o BigIntObject) String() string {
+	return o.Value.String()
+}
+
+type DecimalObject struct {
+	Value *big.Rat
+}
+
+func (o DecimalObject) Type() string {
+	return "DECIMAL_OBJ"
+}
+func (o DecimalObject) String() string {
+	return formatDecimal(o.Value)
+}
+
+func isExact(o interface{}) bool {
+	switch o.(type) {
+	case *BigIntObject, *DecimalObject:
+		return true
+	}
+
+	return false
+}
+
+// toExact converts a numeric object to its exact counterpart. Floating point
+// numbers with an integral value become integers, the rest become decimals
+// holding the shortest representation that round-trips the float.
+func toExact(o interface{}) (Object, bool) {
+	switch o := o.(type) {
+	case *BigIntObject, *DecimalObject:
+		return o.(Object), true
+	case *NumObject:
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			return nil, false
+		}
+
+		if o.Value == math.Trunc(o.Value) {
+			i, _ := big.NewFloat(o.Value).Int(nil)
+			return &BigIntObject{Value: i}, true
+		}
+
+		r, _ := new(big.Rat).SetString(strconv.FormatFloat(o.Value, 'f', -1, 64))
+		return &DecimalObject{Value: r}, true
+	}
+
+	return nil, false
+}
+
+func toRat(o Object) *big.Rat {
+	switch o := o.(type) {
+	case *BigIntObject:
+		return new(big.Rat).SetInt(o.Value)
+	case *DecimalObject:
+		return o.Value
+	}
+
+	return nil
+}
+
+// evalExactInfix evaluates an infix expression where at least one operand is a
+// big integer or a decimal. Integers are promoted to decimals when mixed.
+func (e *Evaluator) evalExactInfix(op string, left, right interface{}) interface{} {
+	l, lok := toExact(left)
+	r, rok := toExact(right)
+
+	if !lok || !rok {
+		switch op {
+		case "==":
+			return &BooleanObject{Value: false}
+		case "!=":
+			return &BooleanObject{Value: true}
+		case "+":
+			e.Errors = append(e.Errors, ErrOperandsNumbersOrStrings)
+		default:
+			e.Errors = append(e.Errors, ErrOperandsNumbers)
+		}
+		return nil
+	}
+
+	li, lInt := l.(*BigIntObject)
+	ri, rInt := r.(*BigIntObject)
+	if lInt && rInt {
+		return e.evalBigIntInfix(op, li.Value, ri.Value)
+	}
+
+	return e.evalDecimalInfix(op, toRat(l), toRat(r))
+}
+func (e *Evaluator) evalBigIntInfix(op string, l, r *big.Int) interface{} {
+	switch op {
+	case "+":
+		return &BigIntObject{Value: new(big.Int).Add(l, r)}
+	case "-":
+		return &BigIntObject{Value: new(big.Int).Sub(l, r)}
+	case "*":
+		return &BigIntObject{Value: new(big.Int).Mul(l, r)}
+	case "/":
+		if r.Sign() == 0 {
+			e.Errors = append(e.Errors, errDivisionByZero)
+			return nil
+		}
+		return &BigIntObject{Value: new(big.Int).Quo(l, r)}
+	}
+
+	return compare(op, l.Cmp(r))
+}
+func (e *Evaluator) evalDecimalInfix(op string, l, r *big.Rat) interface{} {
+	switch op {
+	case "+":
+		return &DecimalObject{Value: new(big.Rat).Add(l, r)}
+	case "-":
+		return &DecimalObject{Value: new(big.Rat).Sub(l, r)}
+	case "*":
+		return &DecimalObject{Value: new(big.Rat).Mul(l, r)}
+	case "/":
+		if r.Sign() == 0 {
+			e.Errors = append(e.Errors, errDivisionByZero)
+			return nil
+		}
+		return &DecimalObject{Value: new(big.Rat).Quo(l, r)}
+	}
+
+	return compare(op, l.Cmp(r))
+}
+
+func compare(op string, cmp int) interface{} {
+	switch op {
+	case "<":
+		return &BooleanObject{Value: cmp < 0}
+	case "<=":
+		return &BooleanObject{Value: cmp <= 0}
+	case ">":
+		return &BooleanObject{Value: cmp > 0}
+	case ">=":
+		return &BooleanObject{Value: cmp >= 0}
+	case "==":
+		return &BooleanObject{Value: cmp == 0}
+	case "!=":
+		return &BooleanObject{Value: cmp != 0}
+	}
+
+	return nil
+}
+
+// formatDecimal prints r with as many fractional digits as its exact decimal
+// expansion needs, falling back to decimalPrecision for repeating fractions.
+func formatDecimal(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	prec := decimalPrecision
+	if digits, ok := terminatingDigits(r.Denom()); ok {
+		prec = digits
+	}
+
+	return trailZeroes(r.FloatString(prec))
+}
+
+// terminatingDigits reports the number of fractional digits of 1/denom if its
+// decimal expansion is finite, i.e. denom has no prime factors but 2 and 5.
+func terminatingDigits(denom *big.Int) (int, bool) {
+	d := new(big.Int).Set(denom)
+	two, five := big.NewInt(2), big.NewInt(5)
+	rem := new(big.Int)
+
+	twos, fives := 0, 0
+	for {
+		if _, m := new(big.Int).QuoRem(d, two, rem); m.Sign() != 0 {
+			break
+		}
+		d.Quo(d, two)
+		twos++
+	}
+	for {
+		if _, m := new(big.Int).QuoRem(d, five, rem); m.Sign() != 0 {
+			break
+		}
+		d.Quo(d, five)
+		fives++
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+
+	return max(twos, fives), true
+}
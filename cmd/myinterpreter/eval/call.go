@@ -0,0 +1,129 @@
+package eval
+
+import (
+	"fmt"
+)
+
+// Callable is implemented by every object that can be invoked with a call
+// expression. Call receives exactly one argument per parameter: nil for an
+// optional parameter that was not passed, and a list of the remaining
+// positional arguments for a rest parameter.
+type Callable interface {
+	Object
+	Parameters() []Parameter
+	Call(e *Evaluator, args []Object) Object
+}
+
+// Parameter describes a parameter of a Callable for argument binding.
+type Parameter struct {
+	Name     string
+	Optional bool // has a default value
+	Rest     bool // collects the remaining positional arguments
+}
+
+type namedArg struct {
+	name  string
+	value Object
+}
+
+// ArityError describes the number of arguments a callable accepts. A negative
+// max means any number of arguments from required up.
+func ArityError(required, max, got int) error {
+	switch {
+	case max < 0:
+		return fmt.Errorf("Expected at least %d arguments but got %d.", required, got)
+	case required == max:
+		return fmt.Errorf("Expected %d arguments but got %d.", required, got)
+	}
+
+	return fmt.Errorf("Expected %d to %d arguments but got %d.", required, max, got)
+}
+
+func arity(params []Parameter) (int, int) {
+	required, max := 0, 0
+	for _, param := range params {
+		switch {
+		case param.Rest:
+			return required, -1
+		case !param.Optional:
+			required++
+		}
+		max++
+	}
+
+	return required, max
+}
+
+func bindArgs(params []Parameter, args []Object, named []namedArg) ([]Object, error) {
+	required, max := arity(params)
+	if max >= 0 && len(args) > max || len(args) < required && len(named) == 0 {
+		return nil, ArityError(required, max, len(args))
+	}
+
+	bound := make([]Object, len(params))
+	for i, param := range params {
+		if param.Rest {
+			rest := &ListObject{}
+			if i < len(args) {
+				rest.Elements = append(rest.Elements, args[i:]...)
+			}
+			bound[i] = rest
+			break
+		}
+
+		if i < len(args) {
+			bound[i] = args[i]
+		}
+	}
+
+	for _, arg := range named {
+		i := paramIndex(params, arg.name)
+		if i < 0 {
+			return nil, fmt.Errorf("Unknown parameter '%s'.", arg.name)
+		}
+
+		if bound[i] != nil {
+			return nil, fmt.Errorf("Argument '%s' was passed more than once.", arg.name)
+		}
+		bound[i] = arg.value
+	}
+
+	for i, param := range params {
+		if bound[i] == nil && !param.Optional {
+			return nil, fmt.Errorf("Missing argument for parameter '%s'.", param.Name)
+		}
+	}
+
+	return bound, nil
+}
+
+// paramIndex returns the index of the parameter that can be passed by name,
+// or -1 if there is none. Rest parameters only take positional arguments.
+func paramIndex(params []Parameter, name string) int {
+	for i, param := range params {
+		if param.Name == name && !param.Rest {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// call invokes fn with positional arguments on behalf of a native, such as a
+// callback passed to it, and returns the first error the call reported.
+func (e *Evaluator) call(fn Callable, args []Object) (Object, error) {
+	bound, err := bindArgs(fn.Parameters(), args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(e.Errors)
+	obj := fn.Call(e, bound)
+	if len(e.Errors) > n {
+		err := e.Errors[n]
+		e.Errors = e.Errors[:n]
+		return nil, err
+	}
+
+	return obj, nil
+}
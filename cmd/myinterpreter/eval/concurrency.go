@@ -0,0 +1,258 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
+)
+
+var errDeadlock = errors.New("Deadlock: every task is blocked on a channel.")
+
+// tasks tracks the goroutines started with spawn and collects the errors
+// they report. Channel operations block on cond, which is signalled whenever
+// a channel changes or a task finishes. Because blocking and finishing are
+// both counted under mu, a deadlock is detected exactly: it is when every
+// live task, the main evaluation included, is blocked.
+type tasks struct {
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	cond     *sync.Cond
+	errors   []error
+	live     int                   // tasks that have not finished
+	waiting  map[*func() bool]bool // conditions of the tasks blocked in a channel operation
+	deadlock bool                  // set once detected, fails every blocked task
+	mainDone bool
+}
+
+func newTasks() *tasks {
+	t := &tasks{live: 1, waiting: make(map[*func() bool]bool)} // the main evaluation is live
+	t.cond = sync.NewCond(&t.mu)
+
+	return t
+}
+
+// block waits on cond until ready reports true. It fails with errDeadlock if
+// every live task is waiting and none of them can continue. mu must be held.
+func (t *tasks) block(ready func() bool) error {
+	t.waiting[&ready] = true
+	defer delete(t.waiting, &ready)
+
+	for !ready() {
+		if t.stuck() {
+			t.deadlock = true
+			t.cond.Broadcast()
+		}
+		if t.deadlock {
+			return errDeadlock
+		}
+		t.cond.Wait()
+	}
+
+	return nil
+}
+
+// stuck reports whether no task can make progress. A waiting task whose
+// condition holds has merely not woken up yet.
+func (t *tasks) stuck() bool {
+	if len(t.waiting) < t.live {
+		return false
+	}
+	for ready := range t.waiting {
+		if (*ready)() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// finish records that a task is done, which may leave the others deadlocked.
+// mu must be held.
+func (t *tasks) finish() {
+	t.live--
+	t.cond.Broadcast()
+}
+
+// Wait blocks until every spawned goroutine has finished and appends the
+// errors they reported to e.Errors. Spawned goroutines that are still blocked
+// on channels once the main evaluation is over fail with a deadlock error.
+func (e *Evaluator) Wait() {
+	e.tasks.mu.Lock()
+	if !e.tasks.mainDone {
+		e.tasks.mainDone = true
+		e.tasks.finish()
+	}
+	e.tasks.mu.Unlock()
+
+	e.tasks.wg.Wait()
+
+	e.tasks.mu.Lock()
+	defer e.tasks.mu.Unlock()
+
+	for _, err := range e.tasks.errors {
+		// every blocked task reports the deadlock, once is enough
+		if err == errDeadlock && slices.Contains(e.Errors, err) {
+			continue
+		}
+		e.Errors = append(e.Errors, err)
+	}
+	e.tasks.errors = nil
+}
+
+func (e *Evaluator) VisitSpawnExpr(node ast.SpawnExpr) interface{} {
+	fn, args, ok := e.evalCallee(node.Call)
+	if !ok {
+		return nil
+	}
+
+	child := &Evaluator{
+		globals: e.globals,
+		env:     e.env,
+		tasks:   e.tasks,
+	}
+
+	e.tasks.mu.Lock()
+	e.tasks.live++
+	e.tasks.mu.Unlock()
+
+	e.tasks.wg.Add(1)
+	go func() {
+		defer e.tasks.wg.Done()
+
+		fn.Call(child, args)
+
+		e.tasks.mu.Lock()
+		defer e.tasks.mu.Unlock()
+		e.tasks.errors = append(e.tasks.errors, child.Errors...)
+		e.tasks.finish()
+	}()
+
+	return &NilObject{}
+}
+
+// ChannelObject passes values between tasks. Its state is guarded by the mu
+// of the tasks it is used from, which every evaluator of a script shares.
+//
+// items holds the buffered values followed by the values of blocked senders.
+// The sender of the i-th value ever sent may continue once fewer than
+// capacity values sent before it are still unreceived; with no capacity it
+// waits for its own value to be received.
+type ChannelObject struct {
+	capacity int
+	items    []Object
+	sent     int // values sent so far
+	received int // values received so far
+	closed   bool
+}
+
+func (o *ChannelObject) Type() string {
+	return "CHANNEL_OBJ"
+}
+func (o *ChannelObject) String() string {
+	return "<channel>"
+}
+func (o *ChannelObject) Get(name string) (Object, bool) {
+	switch name {
+	case "send":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "value"}}, Fn: o.send}, true
+	case "recv":
+		return &NativeFunction{Name: name, Fn: o.recv}, true
+	case "close":
+		return &NativeFunction{Name: name, Fn: o.close}, true
+	}
+
+	return nil, false
+}
+
+// send blocks until the value is received or buffered. Sending on a closed
+// channel, or closing it while the sender waits, is an error.
+func (o *ChannelObject) send(e *Evaluator, args []Object) (Object, error) {
+	e.tasks.mu.Lock()
+	defer e.tasks.mu.Unlock()
+
+	if o.closed {
+		return nil, errors.New("Send on closed channel.")
+	}
+
+	ticket := o.sent
+	o.sent++
+	o.items = append(o.items, args[0])
+	e.tasks.cond.Broadcast()
+
+	err := e.tasks.block(func() bool {
+		return o.closed || ticket < o.received+o.capacity || ticket < o.received
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case ticket >= o.received+o.capacity:
+		// closed before the value was buffered or received
+		return nil, errors.New("Send on closed channel.")
+	}
+
+	return &NilObject{}, nil
+}
+
+// recv blocks until a value is available and returns nil once the channel is
+// closed and drained.
+func (o *ChannelObject) recv(e *Evaluator, _ []Object) (Object, error) {
+	e.tasks.mu.Lock()
+	defer e.tasks.mu.Unlock()
+
+	if err := e.tasks.block(func() bool { return len(o.items) > 0 || o.closed }); err != nil {
+		return nil, err
+	}
+	if len(o.items) == 0 {
+		return &NilObject{}, nil
+	}
+
+	obj := o.items[0]
+	o.items = o.items[1:]
+	o.received++
+	e.tasks.cond.Broadcast()
+
+	return obj, nil
+}
+
+// close wakes blocked senders, whose values are dropped, and receivers.
+// Values that were already buffered can still be received.
+func (o *ChannelObject) close(e *Evaluator, _ []Object) (Object, error) {
+	e.tasks.mu.Lock()
+	defer e.tasks.mu.Unlock()
+
+	if o.closed {
+		return nil, errors.New("Channel is already closed.")
+	}
+
+	o.closed = true
+	if len(o.items) > o.capacity {
+		o.items = o.items[:o.capacity]
+	}
+	e.tasks.cond.Broadcast()
+
+	return &NilObject{}, nil
+}
+
+// maxChannelCapacity bounds the buffer of a channel, which Go allocates up
+// front.
+const maxChannelCapacity = 1 << 20
+
+func nativeChannel(_ *Evaluator, args []Object) (Object, error) {
+	if args[0] == nil {
+		return &ChannelObject{}, nil
+	}
+
+	if n, err := numberArg("Channel", "capacity", args[0]); err == nil && n > maxChannelCapacity {
+		return nil, fmt.Errorf("Channel capacity must not exceed %d.", maxChannelCapacity)
+	}
+
+	capacity, err := intArg("Channel", "capacity", args[0])
+	if err != nil || capacity < 0 {
+		return nil, errors.New("Channel capacity must be a non-negative integer.")
+	}
+
+	return &ChannelObject{capacity: capacity}, nil
+}
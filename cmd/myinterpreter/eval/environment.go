@@ -0,0 +1,54 @@
+package eval
+
+import "sync"
+
+// Environment holds variable bindings. Closures can share an environment
+// between goroutines, so access to the values is synchronized.
+type Environment struct {
+	mu        sync.RWMutex
+	values    map[string]Object
+	enclosing *Environment
+}
+
+func NewEnvironment(enclosing *Environment) *Environment {
+	return &Environment{
+		values:    make(map[string]Object),
+		enclosing: enclosing,
+	}
+}
+
+func (env *Environment) Define(name string, value Object) {
+	env.mu.Lock()
+	defer env.mu.Unlock()
+
+	env.values[name] = value
+}
+func (env *Environment) Get(name string) (Object, bool) {
+	for curr := env; curr != nil; curr = curr.enclosing {
+		curr.mu.RLock()
+		value, ok := curr.values[name]
+		curr.mu.RUnlock()
+
+		if ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+func (env *Environment) Assign(name string, value Object) bool {
+	for curr := env; curr != nil; curr = curr.enclosing {
+		curr.mu.Lock()
+		_, ok := curr.values[name]
+		if ok {
+			curr.values[name] = value
+		}
+		curr.mu.Unlock()
+
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -3,6 +3,7 @@ package eval
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"reflect"
 	"strings"
@@ -10,6 +11,14 @@ import (
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
 )
 
+// Runtime errors that the static type checker reports as well.
+var (
+	ErrOperandNumber            = errors.New("Operand must be a number.")
+	ErrOperandsNumbers          = errors.New("Operands must be numbers.")
+	ErrOperandsNumbersOrStrings = errors.New("Operands must be two numbers or two strings.")
+	ErrNotCallable              = errors.New("Can only call functions and classes.")
+)
+
 type Object interface {
 	Type() string
 	String() string
@@ -58,8 +67,47 @@ func (o StrObject) String() string {
 	return fmt.Sprintf("%s", o.Value)
 }
 
+// PropertyHolder is implemented by objects with properties that can be read
+// with the '.' operator.
+type PropertyHolder interface {
+	Get(name string) (Object, bool)
+}
+
+// Evaluator walks the syntax tree. An Evaluator must only be used by one
+// goroutine; spawned calls run on evaluators of their own which share the
+// global environment and report errors through tasks.
 type Evaluator struct {
-	Errors []error
+	Errors  []error
+	globals *Environment
+	env     *Environment
+	tasks   *tasks
+	fs      sandbox
+	clock   Clock
+}
+
+// Option configures what the natives of an Evaluator may access.
+type Option func(e *Evaluator)
+
+// WithFileSystem lets the file natives access the directory tree under root.
+// Without it they fail with an error.
+func WithFileSystem(root string) Option {
+	return func(e *Evaluator) {
+		e.fs = newSandbox(root)
+	}
+}
+
+func NewEvaluator(opts ...Option) *Evaluator {
+	e := &Evaluator{
+		globals: NewEnvironment(nil),
+		tasks:   newTasks(),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.env = e.globals
+	e.defineNatives()
+
+	return e
 }
 
 func (e *Evaluator) Eval(tree ast.Node) Object {
@@ -80,32 +128,55 @@ func (e *Evaluator) VisitNil(_ ast.NilLiteral) interface{} {
 func (e *Evaluator) VisitNum(node ast.NumLiteral) interface{} {
 	return &NumObject{Value: node.Value}
 }
+func (e *Evaluator) VisitBigInt(node ast.BigIntLiteral) interface{} {
+	return &BigIntObject{Value: node.Value}
+}
+func (e *Evaluator) VisitDecimal(node ast.DecimalLiteral) interface{} {
+	return &DecimalObject{Value: node.Value}
+}
 func (e *Evaluator) VisitString(node ast.StringLiteral) interface{} {
 	return &StrObject{Value: node.Value}
 }
+func (e *Evaluator) VisitInterpolationExpr(node ast.InterpolationExpr) interface{} {
+	var sb strings.Builder
+	for _, part := range node.Parts {
+		obj, ok := part.Accept(e).(Object)
+		if !ok {
+			return nil
+		}
+		sb.WriteString(obj.String())
+	}
+
+	return &StrObject{Value: sb.String()}
+}
 func (e *Evaluator) VisitGroupedExpr(node ast.GroupedExpr) interface{} {
-	expr := node.Value.Accept(e)
-	return expr.(Object)
+	return node.Value.Accept(e)
 }
 func (e *Evaluator) VisitPrefixExpr(node ast.PrefixExpr) interface{} {
+	// an operand without a value has already reported an error
 	expr := node.Right.Accept(e)
 	if expr == nil {
-		panic("can't evaluate prefix expression")
+		return nil
 	}
 
 	switch node.Op {
 	case "-":
-		if expr, ok := expr.(*NumObject); ok {
+		switch expr := expr.(type) {
+		case *NumObject:
 			return &NumObject{Value: -expr.Value}
-		} else {
-			e.Errors = append(e.Errors, errors.New("Operand must be a number."))
+		case *BigIntObject:
+			return &BigIntObject{Value: new(big.Int).Neg(expr.Value)}
+		case *DecimalObject:
+			return &DecimalObject{Value: new(big.Rat).Neg(expr.Value)}
+		default:
+			e.Errors = append(e.Errors, ErrOperandNumber)
 		}
 	case "!":
 		if _, ok := expr.(*NilObject); ok {
 			return &BooleanObject{Value: true}
 		}
 
-		if _, ok := expr.(*NumObject); ok {
+		if _, ok := expr.(*NumObject); ok || isExact(expr) {
 			return &BooleanObject{Value: false}
 		}
 
@@ -123,22 +194,35 @@ func (e *Evaluator) VisitPrefixExpr(node ast.PrefixExpr) interface{} {
 }
 func (e *Evaluator) VisitInfixExpr(node ast.InfixExpr) interface{} {
 	left := node.Left.Accept(e)
+	if left == nil {
+		return nil
+	}
 	right := node.Right.Accept(e)
+	if right == nil {
+		return nil
+	}
 
-	switch node.Op {
+	return e.evalInfix(node.Op, left, right)
+}
+func (e *Evaluator) evalInfix(op string, left, right interface{}) interface{} {
+	if isExact(left) || isExact(right) {
+		return e.evalExactInfix(op, left, right)
+	}
+
+	switch op {
 	case "+":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &NumObject{Value: l.Value + r.Value}
 			}
-			e.Errors = append(e.Errors, errors.New("Operands must be two numbers or two strings."))
+			e.Errors = append(e.Errors, ErrOperandsNumbersOrStrings)
 		}
 
 		if l, ok := left.(*StrObject); ok {
 			if r, ok := right.(*StrObject); ok {
 				return &StrObject{Value: l.Value + r.Value}
 			}
-			e.Errors = append(e.Errors, errors.New("Operands must be two numbers or two strings."))
+			e.Errors = append(e.Errors, ErrOperandsNumbersOrStrings)
 		}
 	case "-":
 		if l, ok := left.(*NumObject); ok {
@@ -146,49 +230,49 @@ func (e *Evaluator) VisitInfixExpr(node ast.InfixExpr) interface{} {
 				return &NumObject{Value: l.Value - r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case "*":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &NumObject{Value: l.Value * r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case "/":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &NumObject{Value: l.Value / r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case "<":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &BooleanObject{Value: l.Value < r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case "<=":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &BooleanObject{Value: l.Value <= r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case ">":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &BooleanObject{Value: l.Value > r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case ">=":
 		if l, ok := left.(*NumObject); ok {
 			if r, ok := right.(*NumObject); ok {
 				return &BooleanObject{Value: l.Value >= r.Value}
 			}
 		}
-		e.Errors = append(e.Errors, errors.New("Operands must be numbers."))
+		e.Errors = append(e.Errors, ErrOperandsNumbers)
 	case "==":
 		if left == nil && right == nil {
 			return &BooleanObject{Value: true}
@@ -196,7 +280,7 @@ func (e *Evaluator) VisitInfixExpr(node ast.InfixExpr) interface{} {
 			return &BooleanObject{Value: false}
 		}
 
-		return &BooleanObject{Value: reflect.DeepEqual(left, right)}
+		return &BooleanObject{Value: equal(left, right)}
 	case "!=":
 		if left == nil && right == nil {
 			return &BooleanObject{Value: false}
@@ -204,8 +288,170 @@ func (e *Evaluator) VisitInfixExpr(node ast.InfixExpr) interface{} {
 			return &BooleanObject{Value: true}
 		}
 
-		return &BooleanObject{Value: !reflect.DeepEqual(left, right)}
+		return &BooleanObject{Value: !equal(left, right)}
+	}
+
+	return nil
+}
+
+// equal compares numbers by value, so NaN is unequal to itself even when both
+// operands are the same object. Lists and maps are equal when their elements
+// are, other values structurally. Channels, functions, readers and the like
+// are only equal to themselves; comparing their contents would also read
+// state that spawned tasks may be changing.
+func equal(left, right interface{}) bool {
+	switch l := left.(type) {
+	case *NumObject:
+		r, ok := right.(*NumObject)
+		return ok && l.Value == r.Value
+	case *ListObject:
+		r, ok := right.(*ListObject)
+		if !ok || len(l.Elements) != len(r.Elements) {
+			return false
+		}
+		for i := range l.Elements {
+			if !equal(l.Elements[i], r.Elements[i]) {
+				return false
+			}
+		}
+		return true
+	case *MapObject:
+		r, ok := right.(*MapObject)
+		if !ok || len(l.Entries) != len(r.Entries) {
+			return false
+		}
+		for key, value := range l.Entries {
+			if other, ok := r.Entries[key]; !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case *StrObject, *BooleanObject, *NilObject, *BigIntObject, *DecimalObject, *TimeObject, *DurationObject:
+		return reflect.DeepEqual(left, right)
+	}
+
+	return left == right
+}
+func (e *Evaluator) VisitIdentifier(node ast.Identifier) interface{} {
+	if obj, ok := e.env.Get(node.Value); ok {
+		return obj
+	}
+	e.Errors = append(e.Errors, fmt.Errorf("Undefined variable '%s'.", node.Value))
+
+	return nil
+}
+func (e *Evaluator) VisitCallExpr(node ast.CallExpr) interface{} {
+	fn, args, ok := e.evalCallee(node)
+	if !ok {
+		return nil
+	}
+
+	return fn.Call(e, args)
+}
+
+// evalCallee evaluates the callee and the arguments of a call and binds the
+// arguments to the callee's parameters.
+func (e *Evaluator) evalCallee(node ast.CallExpr) (Callable, []Object, bool) {
+	// the callee already reported why it has no value
+	callee, ok := node.Callee.Accept(e).(Object)
+	if !ok {
+		return nil, nil, false
+	}
+
+	args := make([]Object, 0, len(node.Args))
+	for _, arg := range node.Args {
+		obj, ok := arg.Accept(e).(Object)
+		if !ok {
+			return nil, nil, false
+		}
+		args = append(args, obj)
+	}
+
+	named := make([]namedArg, 0, len(node.NamedArgs))
+	for _, arg := range node.NamedArgs {
+		obj, ok := arg.Value.Accept(e).(Object)
+		if !ok {
+			return nil, nil, false
+		}
+		named = append(named, namedArg{name: arg.Name.Value, value: obj})
+	}
+
+	fn, ok := callee.(Callable)
+	if !ok {
+		e.Errors = append(e.Errors, ErrNotCallable)
+		return nil, nil, false
+	}
+
+	bound, err := bindArgs(fn.Parameters(), args, named)
+	if err != nil {
+		e.Errors = append(e.Errors, err)
+		return nil, nil, false
+	}
+
+	return fn, bound, true
+}
+func (e *Evaluator) VisitFunctionLiteral(node ast.FunctionLiteral) interface{} {
+	return &FunctionObject{
+		Params:  node.Params,
+		Body:    node.Body,
+		Closure: e.env,
+	}
+}
+func (e *Evaluator) VisitCompoundAssignExpr(node ast.CompoundAssignExpr) interface{} {
+	target := node.Target.(ast.Identifier)
+	curr := target.Accept(e)
+	if curr == nil {
+		return nil
+	}
+
+	right := node.Value.Accept(e)
+	if right == nil {
+		return nil
+	}
+
+	value, ok := e.evalInfix(node.Op, curr, right).(Object)
+	if !ok {
+		return nil
+	}
+	e.env.Assign(target.Value, value)
+
+	return value
+}
+func (e *Evaluator) VisitUpdateExpr(node ast.UpdateExpr) interface{} {
+	target := node.Target.(ast.Identifier)
+	curr := target.Accept(e)
+	if curr == nil {
+		return nil
+	}
+
+	value, ok := e.evalInfix(node.Op[:1], curr, &NumObject{Value: 1}).(Object)
+	if !ok {
+		return nil
+	}
+	e.env.Assign(target.Value, value)
+
+	if node.Prefix {
+		return value
+	}
+
+	return curr
+}
+func (e *Evaluator) VisitGetExpr(node ast.GetExpr) interface{} {
+	obj := node.Object.Accept(e)
+	if obj == nil {
+		return nil
+	}
+
+	holder, ok := obj.(PropertyHolder)
+	if !ok {
+		e.Errors = append(e.Errors, errors.New("Only instances have properties."))
+		return nil
+	}
+
+	if prop, ok := holder.Get(node.Name.Value); ok {
+		return prop
 	}
+	e.Errors = append(e.Errors, fmt.Errorf("Undefined property '%s'.", node.Name.Value))
 
 	return nil
 }
@@ -0,0 +1,338 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/parser"
+)
+
+func TestEvaluator_Eval(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bigIntArithmetic", "123456789012345678901234567890n * 2n", "246913578024691357802469135780"},
+		{"bigIntDivision", "7n / 2n", "3"},
+		{"decimalArithmetic", "1.10d + 2.20d", "3.3"},
+		{"decimalRepeating", "1d / 3d", "0.3333333333333333333333333333"},
+		{"mixedBigIntDecimal", "1n + 0.5d", "1.5"},
+		{"mixedDecimalNumber", "0.1d + 0.2", "0.3"},
+		{"exactComparison", "2n < 1.5d", "false"},
+		{"exactEquality", "1n == 1.0d", "true"},
+		{"exactNegation", "-1.5d", "-1.5"},
+		{"convertToBigInt", "bigint(\"99999999999999999999\") + 1n", "100000000000000000000"},
+		{"convertToDecimal", "decimal(0.1) + decimal(0.2)", "0.3"},
+		{"convertToNumber", "number(3n) / 2", "1.5"},
+		{"callLambda", "((a, b) => a + b)(1, 2)", "3"},
+		{"callLambdaWithoutParams", "(() => \"hi\")()", "hi"},
+		{"closure", "((x) => (y) => x - y)(3)(1)", "2"},
+		{"passLambda", "((f) => f(2))((n) => n * n)", "4"},
+		{"interpolation", "((name) => \"Hello ${name}!\")(\"Bob\")", "Hello Bob!"},
+		{"interpolateNumber", "\"n = ${1 + 2.5}\"", "n = 3.5"},
+		{"compoundAssign", "((a, b) => a *= b += 1)(3, 1)", "6"},
+		{"compoundAssignString", "((s) => s += \"!\")(\"hi\")", "hi!"},
+		{"incrementDecrement", "((a) => \"${a++} ${a} ${++a} ${--a} ${a--} ${a}\")(1)", "1 2 3 2 2 1"},
+		{"incrementBigInt", "((n) => ++n)(9999999999999999999n)", "10000000000000000000"},
+		{"matchAlternative", "match 2 { 1 | 2 => \"small\", _ => \"other\" }", "small"},
+		{"matchBindingGuard", "match 11 { 1 => \"one\", n if n > 10 => \"big ${n}\", _ => \"other\" }", "big 11"},
+		{"matchGuardFails", "match 5 { n if n > 10 => \"big\", _ => \"other\" }", "other"},
+		{"matchString", "match \"x\" { \"y\" => 1, \"x\" => 2 }", "2"},
+		{"matchNegative", "match -1 { -1 => \"neg\", _ => \"pos\" }", "neg"},
+		{"matchListPattern", "match \"a,b\".split(\",\") { [x] => x, [x, y] => \"${y}${x}\" }", "ba"},
+		{"matchListRest", "match \"a,b,c\".split(\",\") { [] => \"empty\", [first, ...rest] => \"${first} ${rest}\" }", "a [\"b\", \"c\"]"},
+		{"matchEmptyList", "match \"\".split(\",\") { [\"\"] => \"blank\", _ => \"other\" }", "blank"},
+		{"matchNestedListAlternative", "match \"b\".split(\",\") { [\"a\" | \"b\"] => \"ab\", _ => \"other\" }", "ab"},
+		{"matchListNotAList", "match \"ab\" { [a, b] => a, _ => \"string\" }", "string"},
+		{"matchExactNumber", "match 1n { 1 => \"one\" }", "one"},
+		{"spawnAndReceive", "((c) => ((_) => c.recv())(spawn ((x) => c.send(x * 2))(21)))(Channel(0))", "42"},
+		{"bufferedChannel", "((c) => \"${c.send(1)} ${c.recv()} ${c.close()} ${c.recv()}\")(Channel(1))", "nil 1 nil nil"},
+		{"defaultParam", "((a, b = a * 10) => a + b)(1)", "11"},
+		{"restParam", "((a, ...rest) => \"${a} ${rest} ${rest.length}\")(1, 2, \"x\")", "1 [2, \"x\"] 2"},
+		{"emptyRest", "((...rest) => rest)()", "[]"},
+		{"namedArgs", "((a, b) => a - b)(b: 1, a: 5)", "4"},
+		{"namedSkipsDefault", "((a, b = 2, c = 3) => \"${a} ${b} ${c}\")(1, c: 4)", "1 2 4"},
+		{"nativeNamedArg", "bigint(value: \"12\")", "12"},
+		{"nativeOptionalArg", "Channel()", "<channel>"},
+		{"stringLength", "\"héllo\".length", "5"},
+		{"stringCase", "\"héllo\".upper() + \"ÀB\".lower()", "HÉLLOàb"},
+		{"stringTrim", "\"  hi  \".trim()", "hi"},
+		{"stringSplit", "\"a,b,,c\".split(\",\")", "[\"a\", \"b\", \"\", \"c\"]"},
+		{"stringSplitRunes", "\"日本語\".split(\"\")", "[\"日\", \"本\", \"語\"]"},
+		{"stringSearch", "\"${\"abc\".contains(\"b\")} ${\"abc\".startsWith(\"b\")} ${\"日本語\".indexOf(\"語\")}\"", "true false 2"},
+		{"stringReplace", "\"a-b-c\".replace(\"-\", \"+\")", "a+b+c"},
+		{"stringSubstring", "\"日本語\".substring(1) + \"日本語\".substring(0, 1)", "本語日"},
+		{"stringRepeat", "\"ab\".repeat(3) + \"\".repeat(2147483647)", "ababab"},
+		{"mathFunctions", "\"${math.sqrt(16)} ${math.pow(2, 10)} ${math.floor(-1.5)} ${math.round(2.5)} ${math.abs(-3n)}\"", "4 1024 -2 3 3"},
+		{"mathMinMax", "\"${math.min(3, 1, 2)} ${math.max(1, 5n, 2.5d)}\"", "1 5"},
+		{"mathLog", "\"${math.log(math.E)} ${math.log(8, 2)}\"", "1 3"},
+		{"mathConstants", "\"${math.isNaN(math.NAN)} ${math.isFinite(math.INF)} ${math.isFinite(math.PI)}\"", "true false true"},
+		{"jsonParseList", "json.parse(\"[1, 2.5, true, null, []]\")", "[1, 2.5, true, nil, []]"},
+		{"jsonParseBigInt", "json.parse(\"[123456789012345678901, -9007199254740993, 9007199254740992, 1.5]\")", "[123456789012345678901, -9007199254740993, 9007199254740992, 1.5]"},
+		{"jsonBigIntArithmetic", "json.parse(\"123456789012345678901\") + 1n", "123456789012345678902"},
+		{"jsonStringifyExact", "json.stringify(12345678901234567890n) + json.stringify(1.10d)", "123456789012345678901.1"},
+		{"jsonStringifyRest", "((...l) => json.stringify(l))(1, \"a<b\", nil)", "[1,\"a<b\",null]"},
+		{"regexMatch", "regex(\"a(b+)(c)?\").match(\"xabbd\")", "[\"abb\", \"bb\", nil]"},
+		{"regexNoMatch", "regex(\"a(b+)\").match(\"xyz\")", "nil"},
+		{"regexFindAll", "regex(\"(\\w)(\\d)\").findAll(\"a1 b2 c\")", "[[\"a1\", \"a\", \"1\"], [\"b2\", \"b\", \"2\"]]"},
+		{"regexReplace", "regex(\"a(?P<bs>b+)\").replace(\"abb ab\", \"<$1|$bs>\")", "<bb|bb> <b|b>"},
+		{"regexReplaceCallback", "regex(\"a(b+)\").replace(\"abb ab\", (m, bs) => \"${bs.length}\")", "2 1"},
+		{"regexReplaceUnicode", "regex(\"é+\").replace(\"日éé本\", (m) => m.upper())", "日ÉÉ本"},
+		{"spawnMany", "((c) => \"${spawn ((x) => c.send(x))(1)}${spawn ((x) => c.send(x))(2)}${c.recv() + c.recv()}\")(Channel(0))", "nilnil3"},
+		{"spawnPipeline", "((c, d) => \"${spawn ((x) => d.send(c.recv() * x))(2)}${c.send(5)}${d.recv()}\")(Channel(0), Channel(0))", "nilnil10"},
+		{"nanConstantUnequal", "math.NAN == math.NAN", "false"},
+		{"nanConstantNotEqual", "math.NAN != math.NAN", "true"},
+		{"nanComputedUnequal", "(0/0) == (0/0)", "false"},
+		{"zeroSignsEqual", "0 == -0", "true"},
+		{"channelsUnequal", "Channel(0) == Channel(0)", "false"},
+		{"channelEqualsItself", "((c) => c == c)(Channel(0))", "true"},
+		{"functionsUnequal", "((x) => x) == ((x) => x)", "false"},
+		{"listsEqualByElement", "\"a,b\".split(\",\") == \"a,b\".split(\",\")", "true"},
+		{"nestedInterpolation", "\"a ${ \"b ${ nil } c\" } d\"", "a b nil c d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := evaluate(t, tt.input)
+			if len(errs) > 0 {
+				t.Fatalf("Eval() errors = %v", errs)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"bigIntDivisionByZero", "1n / 0n", "Division by zero."},
+		{"decimalDivisionByZero", "1d / 0", "Division by zero."},
+		{"exactPlusString", "\"a\" + 1n", "Operands must be two numbers or two strings."},
+		{"exactMinusString", "1.5d - \"a\"", "Operands must be numbers."},
+		{"badConversion", "bigint(\"abc\")", "Cannot convert 'abc' to bigint."},
+		{"badArity", "bigint(1, 2)", "Expected 1 arguments but got 2."},
+		{"notCallable", "1n(2)", "Can only call functions and classes."},
+		{"lambdaArity", "((a) => a)(1, 2)", "Expected 1 arguments but got 2."},
+		{"arityRange", "((a, b = 1) => a)(1, 2, 3)", "Expected 1 to 2 arguments but got 3."},
+		{"arityAtLeast", "((a, ...rest) => a)()", "Expected at least 1 arguments but got 0."},
+		{"unknownNamedArg", "((a) => a)(x: 1)", "Unknown parameter 'x'."},
+		{"namedRestArg", "((...rest) => rest)(rest: 1)", "Unknown parameter 'rest'."},
+		{"duplicateArg", "((a) => a)(1, a: 2)", "Argument 'a' was passed more than once."},
+		{"missingArg", "((a, b) => a)(b: 2)", "Missing argument for parameter 'a'."},
+		{"substringOutOfBounds", "\"abc\".substring(2, 4)", "Substring range 2 to 4 is out of bounds for length 3."},
+		{"stringArgType", "\"abc\".contains(1)", "Argument 'substring' of contains must be a string."},
+		{"repeatTooLong", "\"abc\".repeat(2147483647)", "Result of repeat would exceed 268435456 bytes."},
+		{"intArgType", "\"abc\".repeat(1.5)", "Argument 'count' of repeat must be an integer."},
+		{"undefinedStringProperty", "\"abc\".size", "Undefined property 'size'."},
+		{"mathArgType", "math.sqrt(\"4\")", "Argument 'x' of sqrt must be a number."},
+		{"mathRestArgType", "math.max(1, nil)", "Argument 'values' of max must be a number."},
+		{"mathMinArity", "math.min()", "Expected at least 1 arguments but got 0."},
+		{"jsonInvalid", "json.parse(\"[1, 2\")", "Invalid JSON: unexpected end of JSON input."},
+		{"jsonTrailingData", "json.parse(\"[1] 2\")", "Invalid JSON: unexpected data after the top-level value."},
+		{"jsonEmpty", "json.parse(\"\")", "Invalid JSON: unexpected end of JSON input."},
+		{"jsonOutOfRange", "json.parse(\"1e400\")", "Invalid JSON: number 1e400 is out of range."},
+		{"jsonFunction", "json.stringify((a) => a)", "Cannot convert '<fn>' to JSON."},
+		{"jsonNaN", "json.stringify(math.NAN)", "Cannot convert 'NaN' to JSON."},
+		{"regexInvalid", "regex(\"a(b\")", "Invalid regex 'a(b': missing closing )."},
+		{"regexInvalidCall", "regex(\"a(\").match(\"a\")", "Invalid regex 'a(': missing closing )."},
+		{"undefinedCallee", "f(1)", "Undefined variable 'f'."},
+		{"regexCallbackResult", "regex(\"a\").replace(\"a\", (m) => 1)", "Replacement function must return a string but returned '1'."},
+		{"regexCallbackError", "regex(\"a\").replace(\"a\", (m) => m - 1)", "Operands must be numbers."},
+		{"regexReplacementType", "regex(\"a\").replace(\"a\", nil)", "Argument 'replacement' of replace must be a string or a function."},
+		{"matchNoArm", "match 3 { 1 => 1 }", "No match arm for value '3'."},
+		{"closeTwice", "((c) => c.close() == c.close())(Channel(1))", "Channel is already closed."},
+		{"sendOnClosed", "((c) => c.close() == c.send(1))(Channel(1))", "Send on closed channel."},
+		{"badCapacity", "Channel(1.5)", "Channel capacity must be a non-negative integer."},
+		{"hugeCapacity", "Channel(1000000000000000000)", "Channel capacity must not exceed 1048576."},
+		{"largeCapacity", "Channel(100000000000n)", "Channel capacity must not exceed 1048576."},
+		{"capacityLimit", "Channel(2000000)", "Channel capacity must not exceed 1048576."},
+		{"recvDeadlock", "Channel(0).recv()", "Deadlock: every task is blocked on a channel."},
+		{"sendDeadlock", "((c) => c.send(1))(Channel(0))", "Deadlock: every task is blocked on a channel."},
+		{"fullBufferDeadlock", "((c) => c.send(1) == c.send(2))(Channel(1))", "Deadlock: every task is blocked on a channel."},
+		{"spawnedDeadlock", "spawn ((c) => c.send(1))(Channel(0))", "Deadlock: every task is blocked on a channel."},
+		{"spawnedError", "spawn ((x) => x - \"a\")(1)", "Operands must be numbers."},
+		{"undefinedProperty", "Channel(0).foo", "Undefined property 'foo'."},
+		{"fsDisabled", "readFile(\"a.txt\")", "File system access is disabled. Run with --allow-fs to enable it."},
+		{"groupedUndefined", "(x)", "Undefined variable 'x'."},
+		{"negateUndefined", "-x", "Undefined variable 'x'."},
+		{"notUndefined", "!x", "Undefined variable 'x'."},
+		{"infixUndefined", "x + 1", "Undefined variable 'x'."},
+		{"compoundUndefined", "((a) => a += x)(1)", "Undefined variable 'x'."},
+		{"groupedNativeError", "(readFile(\"a\"))", "File system access is disabled. Run with --allow-fs to enable it."},
+		{"negateNativeError", "-math.sqrt(\"a\")", "Argument 'x' of sqrt must be a number."},
+		{"groupedJSONError", "(json.parse(\"[\"))", "Invalid JSON: unexpected end of JSON input."},
+		{"spawnedGroupedUndefined", "spawn (() => (nope))()", "Undefined variable 'nope'."},
+		{"lambdaScope", "((a) => b)(1)", "Undefined variable 'b'."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := evaluate(t, tt.input)
+			if len(errs) != 1 || errs[0].Error() != tt.want {
+				t.Errorf("Eval() errors = %v, want %v", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluator_FileSystem(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "sub", "lines.txt"), []byte("one\r\ntwo\nthree"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "data.json"), []byte(`{"b": [true], "a": {"z": "x", "y": 1}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(t.TempDir(), filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "sub"), filepath.Join(root, "abs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(t.TempDir(), "pwned.txt"), filepath.Join(root, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("sub", "new.txt"), filepath.Join(root, "danglingIn")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"writeAndRead", "\"${writeFile(\"a.txt\", \"x\")}${appendFile(\"a.txt\", \"y\")}${readFile(\"a.txt\")}\"", "nilnilxy", ""},
+		{"listDir", "listDir(\"sub\")", "[\"lines.txt\"]", ""},
+		{"exists", "\"${exists(\"sub/lines.txt\")} ${exists(\"nope\")}\"", "true false", ""},
+		{"readLines", "((r) => \"${r.next()},${r.next()},${r.next()},${r.hasNext()}\")(Reader(\"sub/lines.txt\"))", "one,two,three,false", ""},
+		{"readPastEnd", "((r) => r.close() == r.next())(Reader(\"sub/lines.txt\"))", "", "Reader has no more lines."},
+		{"sharedReader", "((r, c) => \"${spawn ((_) => c.send(r.next()))(0)}${spawn ((_) => c.send(r.next()))(0)}${c.recv().length + c.recv().length} ${r.next()}\")(Reader(\"sub/lines.txt\"), Channel(0))", "nilnil6 three", ""},
+		{"missingFile", "readFile(\"nope.txt\")", "", "Cannot read 'nope.txt': no such file or directory."},
+		{"parentDir", "readFile(\"../a.txt\")", "", "Path '../a.txt' is outside the file system root."},
+		{"absolutePath", "listDir(\"/\")", "", "Path '/' is outside the file system root."},
+		{"jsonRoundTrip", "json.stringify(json.parse(readFile(\"data.json\")), 1)", "{\n \"a\": {\n  \"y\": 1,\n  \"z\": \"x\"\n },\n \"b\": [\n  true\n ]\n}", ""},
+		{"jsonMapAccess", "json.parse(readFile(\"data.json\")).a.z", "x", ""},
+		{"jsonMapString", "json.parse(readFile(\"data.json\"))", "{\"a\": {\"y\": 1, \"z\": \"x\"}, \"b\": [true]}", ""},
+		{"absoluteSymlinkIn", "readFile(\"abs/lines.txt\").length", "14", ""},
+		{"missingDirOut", "exists(\"link/a/b.txt\")", "", "Path 'link/a/b.txt' is outside the file system root."},
+		{"danglingSymlinkOut", "writeFile(\"dangling\", \"escaped\")", "", "Path 'dangling' is outside the file system root."},
+		{"danglingSymlinkIn", "\"${writeFile(\"danglingIn\", \"x\")}${readFile(\"sub/new.txt\")}\"", "nilx", ""},
+		{"symlinkOut", "writeFile(\"link/a.txt\", \"x\")", "", "Path 'link/a.txt' is outside the file system root."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := evaluate(t, tt.input, WithFileSystem(root))
+			if tt.wantErr != "" {
+				if len(errs) == 0 || errs[0].Error() != tt.wantErr {
+					t.Errorf("Eval() errors = %v, want %v", errs, tt.wantErr)
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("Eval() errors = %v", errs)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Time(t *testing.T) {
+	fixed := time.Date(2024, time.March, 10, 13, 30, 0, 0, time.UTC)
+	clock := func() time.Time { return fixed }
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"clock", "clock()", "1710077400", ""},
+		{"now", "now()", "2024-03-10T13:30:00Z", ""},
+		{"fields", "((t) => \"${t.year}-${t.month}-${t.day} ${t.hour}:${t.minute}:${t.second} ${t.weekday}\")(now())", "2024-3-10 13:30:0 Sunday", ""},
+		{"format", "now().format(\"Jan 2, 2006 at 3:04pm\")", "Mar 10, 2024 at 1:30pm", ""},
+		{"timeZone", "now().in(\"America/New_York\").format(time.DateTime + \" MST\")", "2024-03-10 09:30:00 EDT", ""},
+		{"parseInZone", "time.parse(\"2024-03-10 14:30:00\", time.DateTime, \"Europe/Berlin\").unix == clock()", "true", ""},
+		{"addDuration", "now().add(time.duration(\"36h\")).format(time.RFC3339)", "2024-03-12T01:30:00Z", ""},
+		{"since", "now().since(time.parse(\"2024-03-10\", time.DateOnly))", "13h30m0s", ""},
+		{"durationFields", "((d) => \"${d.hours} ${d.minutes} ${d.seconds} ${d.milliseconds}\")(time.duration(90))", "0.025 1.5 90 90000", ""},
+		{"compare", "((t) => \"${t.before(now())} ${now().after(t)}\")(time.parse(\"2024\", \"2006\"))", "true true", ""},
+		{"badLayout", "time.parse(\"10/03\", time.DateOnly)", "", "Cannot parse '10/03' with layout '2006-01-02'."},
+		{"unknownZone", "now().in(\"Mars/Olympus\")", "", "Unknown time zone 'Mars/Olympus'."},
+		{"badDuration", "time.duration(\"soon\")", "", "Invalid duration 'soon'."},
+		{"durationOverflow", "time.duration(1000000000000000000000)", "", "Duration of 1000000000000000000000 seconds is out of range."},
+		{"durationNaN", "time.duration(0/0)", "", "Duration of NaN seconds is out of range."},
+		{"durationInf", "time.duration(-math.INF)", "", "Duration of -Inf seconds is out of range."},
+		{"durationLimit", "time.duration(9223372036.854775807)", "", "Duration of 9223372036.854776 seconds is out of range."},
+		{"durationLarge", "time.duration(9000000000)", "2500000h0m0s", ""},
+		{"addNumber", "now().add(1)", "", "Argument 'duration' of add must be a duration."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, errs := evaluate(t, tt.input, WithClock(clock))
+			if tt.wantErr != "" {
+				if len(errs) == 0 || errs[0].Error() != tt.wantErr {
+					t.Errorf("Eval() errors = %v, want %v", errs, tt.wantErr)
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("Eval() errors = %v", errs)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluator_FileSystemRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "missing")
+
+	_, errs := evaluate(t, "exists(\"a\")", WithFileSystem(root))
+	want := "Cannot use '" + root + "' as the file system root: no such file or directory."
+	if len(errs) == 0 || errs[0].Error() != want {
+		t.Errorf("Eval() errors = %v, want %v", errs, want)
+	}
+}
+
+func TestJSONStringify_Cycle(t *testing.T) {
+	list := &ListObject{}
+	list.Elements = append(list.Elements, &MapObject{Entries: map[string]Object{"self": list}})
+
+	if _, err := jsonStringify(nil, []Object{list, nil}); err != errCyclicJSON {
+		t.Errorf("jsonStringify() error = %v, want %v", err, errCyclicJSON)
+	}
+
+	shared := &ListObject{}
+	got, err := jsonStringify(nil, []Object{&ListObject{Elements: []Object{shared, shared}}, nil})
+	if err != nil || got.String() != "[[],[]]" {
+		t.Errorf("jsonStringify() = %v, %v, want [[],[]]", got, err)
+	}
+}
+
+func evaluate(t *testing.T, input string, opts ...Option) (Object, []error) {
+	p := parser.NewParser(lexer.NewLexer([]byte(input)))
+	tree := p.ParseExpr(parser.LOWEST)
+	if len(p.Errors) > 0 {
+		t.Fatalf("ParseExpr() errors = %v", p.Errors)
+	}
+
+	e := NewEvaluator(opts...)
+	obj := e.Eval(tree)
+	e.Wait()
+
+	return obj, e.Errors
+}
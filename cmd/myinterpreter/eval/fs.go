@@ -0,0 +1,313 @@
+package eval
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var errFSDisabled = errors.New("File system access is disabled. Run with --allow-fs to enable it.")
+
+// sandbox confines the file natives to the directory tree under root. An
+// empty root disables file system access altogether.
+type sandbox struct {
+	root string // absolute, with symlinks resolved
+	err  error  // why root could not be resolved
+}
+
+func newSandbox(root string) sandbox {
+	abs, err := filepath.Abs(root)
+	if err == nil {
+		abs, err = filepath.EvalSymlinks(abs)
+	}
+	if err != nil {
+		return sandbox{err: fmt.Errorf("Cannot use '%s' as the file system root: %v.", root, unwrapPathError(err))}
+	}
+
+	return sandbox{root: abs}
+}
+
+// resolve maps a script path, relative to the root, to a path on disk. Paths
+// that are absolute, climb out with "..", or lead out through a symlink are
+// rejected.
+func (s sandbox) resolve(path string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.root == "" {
+		return "", errFSDisabled
+	}
+
+	outside := fmt.Errorf("Path '%s' is outside the file system root.", path)
+	if !filepath.IsLocal(path) && filepath.Clean(path) != "." {
+		return "", outside
+	}
+
+	full := filepath.Join(s.root, path)
+	inside, err := s.contains(full, maxSymlinks)
+	if err != nil {
+		return "", fsError("access", path, err)
+	}
+	if !inside {
+		return "", outside
+	}
+
+	return full, nil
+}
+
+// maxSymlinks bounds the dangling symlinks followed by contains, like the
+// limit EvalSymlinks applies to symlinks that resolve.
+const maxSymlinks = 40
+
+// contains reports whether the absolute path full stays under the root once
+// symlinks are followed. Files that do not exist yet are checked through
+// their closest existing ancestor. A dangling symlink on the way is checked
+// through its target, since creating the file would follow it.
+func (s sandbox) contains(full string, links int) (bool, error) {
+	real, err := filepath.EvalSymlinks(full)
+	for p := full; errors.Is(err, fs.ErrNotExist); {
+		info, lerr := os.Lstat(p)
+		switch {
+		case lerr == nil && info.Mode()&fs.ModeSymlink != 0:
+			if links == 0 {
+				return false, errors.New("too many levels of symbolic links")
+			}
+			target, err := os.Readlink(p)
+			if err != nil {
+				return false, err
+			}
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(p), target)
+			}
+			rest, _ := filepath.Rel(p, full)
+			return s.contains(filepath.Join(target, rest), links-1)
+		case lerr == nil:
+			real, err = filepath.EvalSymlinks(p)
+		case errors.Is(lerr, fs.ErrNotExist) && p != filepath.Dir(p):
+			p = filepath.Dir(p)
+		default:
+			return false, lerr
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	rel, err := filepath.Rel(s.root, real)
+	return err == nil && (filepath.IsLocal(rel) || rel == "."), nil
+}
+
+func (s sandbox) natives() []*NativeFunction {
+	path := Parameter{Name: "path"}
+	content := Parameter{Name: "content"}
+
+	return []*NativeFunction{
+		{Name: "readFile", Params: []Parameter{path}, Fn: s.readFile},
+		{Name: "writeFile", Params: []Parameter{path, content}, Fn: s.writeFile(os.O_TRUNC)},
+		{Name: "appendFile", Params: []Parameter{path, content}, Fn: s.writeFile(os.O_APPEND)},
+		{Name: "listDir", Params: []Parameter{{Name: "path", Optional: true}}, Fn: s.listDir},
+		{Name: "exists", Params: []Parameter{path}, Fn: s.exists},
+		{Name: "Reader", Params: []Parameter{path}, Fn: s.reader},
+	}
+}
+
+// pathArg resolves the path argument of the native fn.
+func (s sandbox) pathArg(fn string, arg Object) (string, string, error) {
+	path, err := stringArg(fn, "path", arg)
+	if err != nil {
+		return "", "", err
+	}
+
+	full, err := s.resolve(path)
+	return path, full, err
+}
+
+func (s sandbox) readFile(_ *Evaluator, args []Object) (Object, error) {
+	path, full, err := s.pathArg("readFile", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(full)
+	if err != nil {
+		return nil, fsError("read", path, err)
+	}
+
+	return &StrObject{Value: string(b)}, nil
+}
+func (s sandbox) writeFile(mode int) func(*Evaluator, []Object) (Object, error) {
+	fn := "writeFile"
+	if mode == os.O_APPEND {
+		fn = "appendFile"
+	}
+
+	return func(_ *Evaluator, args []Object) (Object, error) {
+		path, full, err := s.pathArg(fn, args[0])
+		if err != nil {
+			return nil, err
+		}
+		content, err := stringArg(fn, "content", args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := os.OpenFile(full, os.O_WRONLY|os.O_CREATE|mode, 0o644)
+		if err != nil {
+			return nil, fsError("write", path, err)
+		}
+		defer func() { _ = f.Close() }()
+
+		if _, err := f.WriteString(content); err != nil {
+			return nil, fsError("write", path, err)
+		}
+
+		return &NilObject{}, nil
+	}
+}
+func (s sandbox) listDir(_ *Evaluator, args []Object) (Object, error) {
+	arg := args[0]
+	if arg == nil {
+		arg = &StrObject{Value: "."}
+	}
+
+	path, full, err := s.pathArg("listDir", arg)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(full)
+	if err != nil {
+		return nil, fsError("list", path, err)
+	}
+
+	list := &ListObject{}
+	for _, entry := range entries {
+		list.Elements = append(list.Elements, &StrObject{Value: entry.Name()})
+	}
+
+	return list, nil
+}
+func (s sandbox) exists(_ *Evaluator, args []Object) (Object, error) {
+	_, full, err := s.pathArg("exists", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(full)
+	return &BooleanObject{Value: err == nil}, nil
+}
+func (s sandbox) reader(_ *Evaluator, args []Object) (Object, error) {
+	path, full, err := s.pathArg("Reader", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(full)
+	if err != nil {
+		return nil, fsError("read", path, err)
+	}
+
+	r := &ReaderObject{file: f, scanner: bufio.NewScanner(f)}
+	r.advance()
+
+	return r, nil
+}
+
+// fsError describes a failed file operation without the resolved path, which
+// would reveal where the root is.
+func fsError(op, path string, err error) error {
+	return fmt.Errorf("Cannot %s '%s': %v.", op, path, unwrapPathError(err))
+}
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+
+	return err
+}
+
+// ReaderObject iterates over the lines of a file with hasNext and next. The
+// file is closed once the last line has been read, or explicitly with close.
+// A reader may be shared with spawned tasks, so mu guards all of its state.
+type ReaderObject struct {
+	mu      sync.Mutex
+	file    *os.File
+	scanner *bufio.Scanner
+	line    *string // the line next returns, nil at the end
+	err     error
+}
+
+func (o *ReaderObject) Type() string {
+	return "READER_OBJ"
+}
+func (o *ReaderObject) String() string {
+	return "<reader>"
+}
+func (o *ReaderObject) Get(name string) (Object, bool) {
+	switch name {
+	case "hasNext":
+		return &NativeFunction{Name: name, Fn: o.hasNext}, true
+	case "next":
+		return &NativeFunction{Name: name, Fn: o.next}, true
+	case "close":
+		return &NativeFunction{Name: name, Fn: o.close}, true
+	}
+
+	return nil, false
+}
+
+// advance reads the next line. The caller must hold o.mu.
+func (o *ReaderObject) advance() {
+	if o.file != nil && o.scanner.Scan() {
+		line := strings.TrimSuffix(o.scanner.Text(), "\r")
+		o.line = &line
+		return
+	}
+
+	o.line = nil
+	o.err = o.scanner.Err()
+	o.closeFile()
+}
+func (o *ReaderObject) closeFile() {
+	if o.file != nil {
+		_ = o.file.Close()
+		o.file = nil
+		o.line = nil
+	}
+}
+func (o *ReaderObject) hasNext(_ *Evaluator, _ []Object) (Object, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return &BooleanObject{Value: o.line != nil}, nil
+}
+func (o *ReaderObject) next(_ *Evaluator, _ []Object) (Object, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.err != nil {
+		return nil, fmt.Errorf("Cannot read line: %v.", o.err)
+	}
+	if o.line == nil {
+		return nil, errors.New("Reader has no more lines.")
+	}
+
+	line := *o.line
+	o.advance()
+
+	return &StrObject{Value: line}, nil
+}
+func (o *ReaderObject) close(_ *Evaluator, _ []Object) (Object, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.closeFile()
+
+	return &NilObject{}, nil
+}
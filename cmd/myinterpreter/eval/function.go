@@ -0,0 +1,54 @@
+package eval
+
+import (
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
+)
+
+// FunctionObject is a function defined in a script. It captures the
+// environment it was created in, so free variables of the body resolve to
+// the bindings visible at the definition site.
+type FunctionObject struct {
+	Params  []ast.Param
+	Body    ast.Node
+	Closure *Environment
+}
+
+func (o FunctionObject) Type() string {
+	return "FUNCTION_OBJ"
+}
+func (o FunctionObject) String() string {
+	return "<fn>"
+}
+func (o FunctionObject) Parameters() []Parameter {
+	params := make([]Parameter, 0, len(o.Params))
+	for _, param := range o.Params {
+		params = append(params, Parameter{
+			Name:     param.Name.Value,
+			Optional: param.Default != nil,
+			Rest:     param.Rest,
+		})
+	}
+
+	return params
+}
+func (o FunctionObject) Call(e *Evaluator, args []Object) Object {
+	prev := e.env
+	e.env = NewEnvironment(o.Closure)
+	defer func() { e.env = prev }()
+
+	// defaults are evaluated on every call and can refer to earlier parameters
+	for i, param := range o.Params {
+		arg := args[i]
+		if arg == nil {
+			var ok bool
+			if arg, ok = param.Default.Accept(e).(Object); !ok {
+				return nil
+			}
+		}
+		e.env.Define(param.Name.Value, arg)
+	}
+
+	obj, _ := o.Body.Accept(e).(Object)
+
+	return obj
+}
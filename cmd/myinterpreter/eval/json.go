@@ -0,0 +1,185 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+var errCyclicJSON = errors.New("Cannot convert a cyclic structure to JSON.")
+
+func jsonNamespace() *NamespaceObject {
+	natives := []*NativeFunction{
+		{Name: "parse", Params: []Parameter{{Name: "text"}}, Fn: jsonParse},
+		{Name: "stringify", Params: []Parameter{{Name: "value"}, {Name: "indent", Optional: true}}, Fn: jsonStringify},
+	}
+
+	return newNamespace("json", natives, nil)
+}
+
+// maxExactFloatInt is the largest magnitude below which float64 represents
+// every integer exactly.
+const maxExactFloatInt = 1 << 53
+
+// jsonParse decodes a JSON document into maps, lists, numbers, strings,
+// booleans and nil. Integers too large for a number to hold exactly become
+// big integers.
+func jsonParse(_ *Evaluator, args []Object) (Object, error) {
+	text, err := stringArg("parse", "text", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.New("Invalid JSON: unexpected end of JSON input.")
+		}
+		return nil, fmt.Errorf("Invalid JSON: %v.", err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("Invalid JSON: unexpected data after the top-level value.")
+	}
+
+	return fromJSON(v)
+}
+func fromJSON(v interface{}) (Object, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := &MapObject{Entries: make(map[string]Object, len(v))}
+		for key, value := range v {
+			obj, err := fromJSON(value)
+			if err != nil {
+				return nil, err
+			}
+			m.Entries[key] = obj
+		}
+		return m, nil
+	case []interface{}:
+		list := &ListObject{Elements: make([]Object, 0, len(v))}
+		for _, elem := range v {
+			obj, err := fromJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			list.Elements = append(list.Elements, obj)
+		}
+		return list, nil
+	case json.Number:
+		return fromJSONNumber(v)
+	case string:
+		return &StrObject{Value: v}, nil
+	case bool:
+		return &BooleanObject{Value: v}, nil
+	}
+
+	return &NilObject{}, nil
+}
+func fromJSONNumber(n json.Number) (Object, error) {
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		if i.CmpAbs(big.NewInt(maxExactFloatInt)) > 0 {
+			return &BigIntObject{Value: i}, nil
+		}
+		f, _ := new(big.Float).SetInt(i).Float64()
+		return &NumObject{Value: f}, nil
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid JSON: number %s is out of range.", n)
+	}
+
+	return &NumObject{Value: f}, nil
+}
+
+// jsonStringify encodes a value as JSON with map keys in sorted order. indent
+// is the number of spaces per level; without it the output is compact.
+func jsonStringify(_ *Evaluator, args []Object) (Object, error) {
+	v, err := toJSON(args[0], make(map[Object]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if args[1] != nil {
+		indent, err := intArg("stringify", "indent", args[1])
+		if err != nil {
+			return nil, err
+		}
+		if indent < 0 {
+			return nil, errors.New("Argument 'indent' of stringify must not be negative.")
+		}
+		enc.SetIndent("", strings.Repeat(" ", indent))
+	}
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return &StrObject{Value: strings.TrimSuffix(buf.String(), "\n")}, nil
+}
+
+// toJSON converts o to a value encoding/json can encode. visiting holds the
+// collections being converted, to detect cycles.
+func toJSON(o Object, visiting map[Object]bool) (interface{}, error) {
+	switch o := o.(type) {
+	case *NilObject:
+		return nil, nil
+	case *BooleanObject:
+		return o.Value, nil
+	case *StrObject:
+		return o.Value, nil
+	case *NumObject:
+		if math.IsNaN(o.Value) || math.IsInf(o.Value, 0) {
+			break
+		}
+		return o.Value, nil
+	case *BigIntObject, *DecimalObject:
+		return json.Number(o.String()), nil
+	case *ListObject:
+		if visiting[o] {
+			return nil, errCyclicJSON
+		}
+		visiting[o] = true
+		defer delete(visiting, o)
+
+		elems := make([]interface{}, 0, len(o.Elements))
+		for _, elem := range o.Elements {
+			v, err := toJSON(elem, visiting)
+			if err != nil {
+				return nil, err
+			}
+			elems = append(elems, v)
+		}
+		return elems, nil
+	case *MapObject:
+		if visiting[o] {
+			return nil, errCyclicJSON
+		}
+		visiting[o] = true
+		defer delete(visiting, o)
+
+		// encoding/json sorts map keys, which keeps the output stable.
+		entries := make(map[string]interface{}, len(o.Entries))
+		for key, value := range o.Entries {
+			v, err := toJSON(value, visiting)
+			if err != nil {
+				return nil, err
+			}
+			entries[key] = v
+		}
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("Cannot convert '%v' to JSON.", o)
+}
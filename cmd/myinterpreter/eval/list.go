@@ -0,0 +1,39 @@
+package eval
+
+import (
+	"strings"
+)
+
+type ListObject struct {
+	Elements []Object
+}
+
+func (o *ListObject) Type() string {
+	return "LIST_OBJ"
+}
+func (o *ListObject) String() string {
+	elems := make([]string, 0, len(o.Elements))
+	for _, elem := range o.Elements {
+		elems = append(elems, inspect(elem))
+	}
+
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+func (o *ListObject) Get(name string) (Object, bool) {
+	switch name {
+	case "length":
+		return &NumObject{Value: float64(len(o.Elements))}, true
+	}
+
+	return nil, false
+}
+
+// inspect formats an element of a collection, quoting strings so they can be
+// told apart from other values.
+func inspect(o Object) string {
+	if str, ok := o.(*StrObject); ok {
+		return `"` + str.Value + `"`
+	}
+
+	return o.String()
+}
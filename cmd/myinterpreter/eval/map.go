@@ -0,0 +1,39 @@
+package eval
+
+import (
+	"sort"
+	"strings"
+)
+
+// MapObject maps string keys to values. Its entries are read as properties,
+// e.g. user.name.
+type MapObject struct {
+	Entries map[string]Object
+}
+
+func (o *MapObject) Type() string {
+	return "MAP_OBJ"
+}
+func (o *MapObject) String() string {
+	entries := make([]string, 0, len(o.Entries))
+	for _, key := range o.Keys() {
+		entries = append(entries, `"`+key+`": `+inspect(o.Entries[key]))
+	}
+
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+func (o *MapObject) Get(name string) (Object, bool) {
+	value, ok := o.Entries[name]
+	return value, ok
+}
+
+// Keys returns the keys of the map in sorted order.
+func (o *MapObject) Keys() []string {
+	keys := make([]string, 0, len(o.Entries))
+	for key := range o.Entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,85 @@
+package eval
+
+import (
+	"fmt"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
+)
+
+func (e *Evaluator) VisitMatchExpr(node ast.MatchExpr) interface{} {
+	subject, ok := node.Subject.Accept(e).(Object)
+	if !ok {
+		return nil
+	}
+
+	prev := e.env
+	defer func() { e.env = prev }()
+
+	for _, arm := range node.Arms {
+		e.env = NewEnvironment(prev)
+		if !e.matchPattern(arm.Pattern, subject) {
+			continue
+		}
+
+		if arm.Guard != nil && !isTruthy(arm.Guard.Accept(e)) {
+			continue
+		}
+
+		return arm.Body.Accept(e)
+	}
+
+	e.Errors = append(e.Errors, fmt.Errorf("No match arm for value '%v'.", subject))
+
+	return nil
+}
+
+// matchPattern reports whether subject matches pattern, defining the names
+// bound by the pattern in the current environment.
+func (e *Evaluator) matchPattern(pattern ast.Pattern, subject Object) bool {
+	switch pattern := pattern.(type) {
+	case ast.WildcardPattern:
+		return true
+	case ast.BindingPattern:
+		e.env.Define(pattern.Name.Value, subject)
+		return true
+	case ast.LiteralPattern:
+		eq, _ := e.evalInfix("==", subject, pattern.Value.Accept(e)).(*BooleanObject)
+		return eq != nil && eq.Value
+	case ast.AlternativePattern:
+		for _, alt := range pattern.Alternatives {
+			if e.matchPattern(alt, subject) {
+				return true
+			}
+		}
+	case ast.ListPattern:
+		list, ok := subject.(*ListObject)
+		n := len(pattern.Elements)
+		if !ok || len(list.Elements) < n || pattern.Rest == nil && len(list.Elements) != n {
+			return false
+		}
+
+		for i, elem := range pattern.Elements {
+			if !e.matchPattern(elem, list.Elements[i]) {
+				return false
+			}
+		}
+		if pattern.Rest != nil {
+			rest := &ListObject{Elements: append([]Object(nil), list.Elements[n:]...)}
+			return e.matchPattern(pattern.Rest, rest)
+		}
+		return true
+	}
+
+	return false
+}
+
+func isTruthy(obj interface{}) bool {
+	switch obj := obj.(type) {
+	case nil, *NilObject:
+		return false
+	case *BooleanObject:
+		return obj.Value
+	}
+
+	return true
+}
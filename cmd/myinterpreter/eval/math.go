@@ -0,0 +1,125 @@
+package eval
+
+import (
+	"math"
+)
+
+// mathNamespace returns the math global. Its functions accept any kind of
+// number and compute with floating point, so big integers and decimals are
+// converted first.
+func mathNamespace() *NamespaceObject {
+	natives := []*NativeFunction{
+		unaryMath("sqrt", math.Sqrt),
+		unaryMath("floor", math.Floor),
+		unaryMath("ceil", math.Ceil),
+		unaryMath("round", math.Round),
+		unaryMath("abs", math.Abs),
+		unaryMath("sin", math.Sin),
+		unaryMath("cos", math.Cos),
+		unaryMath("tan", math.Tan),
+		unaryMath("asin", math.Asin),
+		unaryMath("acos", math.Acos),
+		unaryMath("atan", math.Atan),
+		binaryMath("atan2", "y", "x", math.Atan2),
+		binaryMath("pow", "base", "exponent", math.Pow),
+		{Name: "log", Params: []Parameter{{Name: "x"}, {Name: "base", Optional: true}}, Fn: mathLog},
+		{Name: "min", Params: []Parameter{{Name: "value"}, {Name: "values", Rest: true}}, Fn: mathExtremum("min", math.Min)},
+		{Name: "max", Params: []Parameter{{Name: "value"}, {Name: "values", Rest: true}}, Fn: mathExtremum("max", math.Max)},
+		mathPredicate("isNaN", math.IsNaN),
+		mathPredicate("isFinite", func(x float64) bool { return !math.IsNaN(x) && !math.IsInf(x, 0) }),
+	}
+
+	return newNamespace("math", natives, map[string]Object{
+		"PI":  &NumObject{Value: math.Pi},
+		"E":   &NumObject{Value: math.E},
+		"INF": &NumObject{Value: math.Inf(1)},
+		"NAN": &NumObject{Value: math.NaN()},
+	})
+}
+
+func unaryMath(name string, fn func(float64) float64) *NativeFunction {
+	return &NativeFunction{
+		Name:   name,
+		Params: []Parameter{{Name: "x"}},
+		Fn: func(_ *Evaluator, args []Object) (Object, error) {
+			x, err := numberArg(name, "x", args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return &NumObject{Value: fn(x)}, nil
+		},
+	}
+}
+func binaryMath(name, a, b string, fn func(float64, float64) float64) *NativeFunction {
+	return &NativeFunction{
+		Name:   name,
+		Params: []Parameter{{Name: a}, {Name: b}},
+		Fn: func(_ *Evaluator, args []Object) (Object, error) {
+			x, err := numberArg(name, a, args[0])
+			if err != nil {
+				return nil, err
+			}
+			y, err := numberArg(name, b, args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return &NumObject{Value: fn(x, y)}, nil
+		},
+	}
+}
+func mathPredicate(name string, fn func(float64) bool) *NativeFunction {
+	return &NativeFunction{
+		Name:   name,
+		Params: []Parameter{{Name: "x"}},
+		Fn: func(_ *Evaluator, args []Object) (Object, error) {
+			x, err := numberArg(name, "x", args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			return &BooleanObject{Value: fn(x)}, nil
+		},
+	}
+}
+
+// mathExtremum folds fn over one or more arguments. Like math.Min and
+// math.Max, the result is NaN if any argument is.
+func mathExtremum(name string, fn func(float64, float64) float64) func(*Evaluator, []Object) (Object, error) {
+	return func(_ *Evaluator, args []Object) (Object, error) {
+		result, err := numberArg(name, "value", args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, arg := range args[1].(*ListObject).Elements {
+			x, err := numberArg(name, "values", arg)
+			if err != nil {
+				return nil, err
+			}
+			result = fn(result, x)
+		}
+
+		return &NumObject{Value: result}, nil
+	}
+}
+
+// mathLog returns the natural logarithm of x, or the logarithm to base if
+// one is passed.
+func mathLog(_ *Evaluator, args []Object) (Object, error) {
+	x, err := numberArg("log", "x", args[0])
+	if err != nil {
+		return nil, err
+	}
+	if args[1] == nil {
+		return &NumObject{Value: math.Log(x)}, nil
+	}
+
+	base, err := numberArg("log", "base", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return &NumObject{Value: math.Log(x) / math.Log(base)}, nil
+}
@@ -0,0 +1,180 @@
+package eval
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NativeFunction is a function implemented in Go and exposed to scripts.
+// Fn receives one argument per parameter, bound as described by Callable.
+type NativeFunction struct {
+	Name   string
+	Params []Parameter
+	Fn     func(e *Evaluator, args []Object) (Object, error)
+}
+
+func (o NativeFunction) Type() string {
+	return "NATIVE_FN_OBJ"
+}
+func (o NativeFunction) String() string {
+	return "<native fn>"
+}
+func (o NativeFunction) Parameters() []Parameter {
+	return o.Params
+}
+func (o NativeFunction) Call(e *Evaluator, args []Object) Object {
+	obj, err := o.Fn(e, args)
+	if err != nil {
+		e.Errors = append(e.Errors, err)
+		return nil
+	}
+
+	return obj
+}
+
+// NamespaceObject groups related natives and constants under one global
+// name, e.g. math.sqrt.
+type NamespaceObject struct {
+	Name    string
+	Members map[string]Object
+}
+
+func (o *NamespaceObject) Type() string {
+	return "NAMESPACE_OBJ"
+}
+func (o *NamespaceObject) String() string {
+	return "<namespace " + o.Name + ">"
+}
+func (o *NamespaceObject) Get(name string) (Object, bool) {
+	member, ok := o.Members[name]
+	return member, ok
+}
+
+func newNamespace(name string, natives []*NativeFunction, constants map[string]Object) *NamespaceObject {
+	ns := &NamespaceObject{Name: name, Members: make(map[string]Object)}
+	for _, n := range natives {
+		ns.Members[n.Name] = n
+	}
+	for name, c := range constants {
+		ns.Members[name] = c
+	}
+
+	return ns
+}
+
+func (e *Evaluator) defineNatives() {
+	natives := []*NativeFunction{
+		{Name: "bigint", Params: []Parameter{{Name: "value"}}, Fn: nativeBigInt},
+		{Name: "decimal", Params: []Parameter{{Name: "value"}}, Fn: nativeDecimal},
+		{Name: "number", Params: []Parameter{{Name: "value"}}, Fn: nativeNumber},
+		{Name: "Channel", Params: []Parameter{{Name: "capacity", Optional: true}}, Fn: nativeChannel},
+		{Name: "regex", Params: []Parameter{{Name: "pattern"}}, Fn: nativeRegex},
+	}
+	natives = append(natives, e.fs.natives()...)
+	natives = append(natives, e.timeNatives()...)
+
+	for _, n := range natives {
+		e.globals.Define(n.Name, n)
+	}
+	e.globals.Define("math", mathNamespace())
+	e.globals.Define("json", jsonNamespace())
+	e.globals.Define("time", timeNamespace())
+}
+
+func nativeBigInt(_ *Evaluator, args []Object) (Object, error) {
+	switch arg := args[0].(type) {
+	case *BigIntObject:
+		return arg, nil
+	case *DecimalObject:
+		return &BigIntObject{Value: new(big.Int).Quo(arg.Value.Num(), arg.Value.Denom())}, nil
+	case *NumObject:
+		if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+			break
+		}
+		i, _ := big.NewFloat(arg.Value).Int(nil)
+		return &BigIntObject{Value: i}, nil
+	case *StrObject:
+		if i, ok := new(big.Int).SetString(strings.TrimSpace(arg.Value), 10); ok {
+			return &BigIntObject{Value: i}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot convert '%v' to bigint.", args[0])
+}
+func nativeDecimal(_ *Evaluator, args []Object) (Object, error) {
+	switch arg := args[0].(type) {
+	case *BigIntObject:
+		return &DecimalObject{Value: new(big.Rat).SetInt(arg.Value)}, nil
+	case *DecimalObject:
+		return arg, nil
+	case *NumObject:
+		if d, ok := toExact(arg); ok {
+			return &DecimalObject{Value: toRat(d)}, nil
+		}
+	case *StrObject:
+		if r, ok := new(big.Rat).SetString(strings.TrimSpace(arg.Value)); ok {
+			return &DecimalObject{Value: r}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot convert '%v' to decimal.", args[0])
+}
+func nativeNumber(_ *Evaluator, args []Object) (Object, error) {
+	switch arg := args[0].(type) {
+	case *BigIntObject:
+		f, _ := new(big.Float).SetInt(arg.Value).Float64()
+		return &NumObject{Value: f}, nil
+	case *DecimalObject:
+		f, _ := arg.Value.Float64()
+		return &NumObject{Value: f}, nil
+	case *NumObject:
+		return arg, nil
+	case *StrObject:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(arg.Value), 64); err == nil {
+			return &NumObject{Value: f}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot convert '%v' to number.", args[0])
+}
+
+// stringArg, numberArg and intArg check the type of an argument passed to the native
+// fn, naming the offending parameter in the error.
+func stringArg(fn, param string, arg Object) (string, error) {
+	if s, ok := arg.(*StrObject); ok {
+		return s.Value, nil
+	}
+
+	return "", fmt.Errorf("Argument '%s' of %s must be a string.", param, fn)
+}
+func numberArg(fn, param string, arg Object) (float64, error) {
+	switch arg := arg.(type) {
+	case *NumObject:
+		return arg.Value, nil
+	case *BigIntObject:
+		f, _ := new(big.Float).SetInt(arg.Value).Float64()
+		return f, nil
+	case *DecimalObject:
+		f, _ := arg.Value.Float64()
+		return f, nil
+	}
+
+	return 0, fmt.Errorf("Argument '%s' of %s must be a number.", param, fn)
+}
+func intArg(fn, param string, arg Object) (int, error) {
+	switch arg := arg.(type) {
+	case *NumObject:
+		if arg.Value == math.Trunc(arg.Value) && math.Abs(arg.Value) <= math.MaxInt32 {
+			return int(arg.Value), nil
+		}
+	case *BigIntObject:
+		if arg.Value.IsInt64() && arg.Value.Int64() >= math.MinInt32 && arg.Value.Int64() <= math.MaxInt32 {
+			return int(arg.Value.Int64()), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Argument '%s' of %s must be an integer.", param, fn)
+}
@@ -0,0 +1,145 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// RegexObject is a compiled regular expression using the syntax of Go's
+// regexp package.
+type RegexObject struct {
+	re *regexp.Regexp
+}
+
+func (o *RegexObject) Type() string {
+	return "REGEX_OBJ"
+}
+func (o *RegexObject) String() string {
+	return "<regex " + o.re.String() + ">"
+}
+func (o *RegexObject) Get(name string) (Object, bool) {
+	switch name {
+	case "pattern":
+		return &StrObject{Value: o.re.String()}, true
+	case "match":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "text"}}, Fn: o.match}, true
+	case "findAll":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "text"}}, Fn: o.findAll}, true
+	case "replace":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "text"}, {Name: "replacement"}}, Fn: o.replace}, true
+	}
+
+	return nil, false
+}
+
+func nativeRegex(_ *Evaluator, args []Object) (Object, error) {
+	pattern, err := stringArg("regex", "pattern", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) {
+			return nil, fmt.Errorf("Invalid regex '%s': %s.", pattern, syntaxErr.Code)
+		}
+		return nil, fmt.Errorf("Invalid regex '%s'.", pattern)
+	}
+
+	return &RegexObject{re: re}, nil
+}
+
+// groups returns the whole match followed by the capture groups. Groups that
+// did not take part in the match are nil.
+func groups(text string, loc []int) []Object {
+	objs := make([]Object, 0, len(loc)/2)
+	for i := 0; i < len(loc); i += 2 {
+		if loc[i] < 0 {
+			objs = append(objs, &NilObject{})
+			continue
+		}
+		objs = append(objs, &StrObject{Value: text[loc[i]:loc[i+1]]})
+	}
+
+	return objs
+}
+
+// match returns the groups of the first match, or nil if there is none.
+func (o *RegexObject) match(_ *Evaluator, args []Object) (Object, error) {
+	text, err := stringArg("match", "text", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	loc := o.re.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return &NilObject{}, nil
+	}
+
+	return &ListObject{Elements: groups(text, loc)}, nil
+}
+
+// findAll returns the groups of every match, one list per match.
+func (o *RegexObject) findAll(_ *Evaluator, args []Object) (Object, error) {
+	text, err := stringArg("findAll", "text", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	matches := &ListObject{}
+	for _, loc := range o.re.FindAllStringSubmatchIndex(text, -1) {
+		matches.Elements = append(matches.Elements, &ListObject{Elements: groups(text, loc)})
+	}
+
+	return matches, nil
+}
+
+// replace replaces every match. A string replacement may refer to groups as
+// $1 or $name. A function replacement is called with the whole match
+// followed by the groups, as many as it has parameters for, and must return
+// a string.
+func (o *RegexObject) replace(e *Evaluator, args []Object) (Object, error) {
+	text, err := stringArg("replace", "text", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := args[1].(Callable)
+	if !ok {
+		repl, err := stringArg("replace", "replacement", args[1])
+		if err != nil {
+			return nil, errors.New("Argument 'replacement' of replace must be a string or a function.")
+		}
+		return &StrObject{Value: o.re.ReplaceAllString(text, repl)}, nil
+	}
+
+	_, max := arity(fn.Parameters())
+
+	var result []byte
+	last := 0
+	for _, loc := range o.re.FindAllStringSubmatchIndex(text, -1) {
+		callArgs := groups(text, loc)
+		if max >= 0 && len(callArgs) > max {
+			callArgs = callArgs[:max]
+		}
+
+		obj, err := e.call(fn, callArgs)
+		if err != nil {
+			return nil, err
+		}
+		repl, ok := obj.(*StrObject)
+		if !ok {
+			return nil, fmt.Errorf("Replacement function must return a string but returned '%v'.", obj)
+		}
+
+		result = append(result, text[last:loc[0]]...)
+		result = append(result, repl.Value...)
+		last = loc[1]
+	}
+	result = append(result, text[last:]...)
+
+	return &StrObject{Value: string(result)}, nil
+}
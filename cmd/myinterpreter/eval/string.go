@@ -0,0 +1,126 @@
+package eval
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxStringLength bounds the strings that methods like repeat build, so a
+// script cannot exhaust memory with a single call.
+const maxStringLength = 1 << 28
+
+// Get resolves the built-in properties of strings. length is a property, the
+// rest are methods bound to the string. Lengths and indices count characters
+// (runes), not bytes.
+func (o StrObject) Get(name string) (Object, bool) {
+	s := o.Value
+
+	var params []Parameter
+	var fn func(args []Object) (Object, error)
+
+	switch name {
+	case "length":
+		return &NumObject{Value: float64(utf8.RuneCountInString(s))}, true
+	case "upper":
+		fn = func(_ []Object) (Object, error) { return &StrObject{Value: strings.ToUpper(s)}, nil }
+	case "lower":
+		fn = func(_ []Object) (Object, error) { return &StrObject{Value: strings.ToLower(s)}, nil }
+	case "trim":
+		fn = func(_ []Object) (Object, error) { return &StrObject{Value: strings.TrimSpace(s)}, nil }
+	case "split":
+		params = []Parameter{{Name: "separator"}}
+		fn = func(args []Object) (Object, error) {
+			sep, err := stringArg(name, "separator", args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			list := &ListObject{}
+			for _, part := range strings.Split(s, sep) {
+				list.Elements = append(list.Elements, &StrObject{Value: part})
+			}
+			return list, nil
+		}
+	case "contains", "startsWith", "indexOf":
+		params = []Parameter{{Name: "substring"}}
+		fn = func(args []Object) (Object, error) {
+			sub, err := stringArg(name, "substring", args[0])
+			if err != nil {
+				return nil, err
+			}
+
+			switch name {
+			case "contains":
+				return &BooleanObject{Value: strings.Contains(s, sub)}, nil
+			case "startsWith":
+				return &BooleanObject{Value: strings.HasPrefix(s, sub)}, nil
+			}
+
+			i := strings.Index(s, sub)
+			if i >= 0 {
+				i = utf8.RuneCountInString(s[:i])
+			}
+			return &NumObject{Value: float64(i)}, nil
+		}
+	case "replace":
+		params = []Parameter{{Name: "old"}, {Name: "new"}}
+		fn = func(args []Object) (Object, error) {
+			old, err := stringArg(name, "old", args[0])
+			if err != nil {
+				return nil, err
+			}
+			repl, err := stringArg(name, "new", args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return &StrObject{Value: strings.ReplaceAll(s, old, repl)}, nil
+		}
+	case "substring":
+		params = []Parameter{{Name: "start"}, {Name: "end", Optional: true}}
+		fn = func(args []Object) (Object, error) {
+			runes := []rune(s)
+
+			start, err := intArg(name, "start", args[0])
+			if err != nil {
+				return nil, err
+			}
+			end := len(runes)
+			if args[1] != nil {
+				if end, err = intArg(name, "end", args[1]); err != nil {
+					return nil, err
+				}
+			}
+
+			if start < 0 || end < start || end > len(runes) {
+				return nil, fmt.Errorf("Substring range %d to %d is out of bounds for length %d.", start, end, len(runes))
+			}
+			return &StrObject{Value: string(runes[start:end])}, nil
+		}
+	case "repeat":
+		params = []Parameter{{Name: "count"}}
+		fn = func(args []Object) (Object, error) {
+			count, err := intArg(name, "count", args[0])
+			if err != nil {
+				return nil, err
+			}
+			if count < 0 {
+				return nil, fmt.Errorf("Argument 'count' of %s must not be negative.", name)
+			}
+			if count > 0 && len(s) > maxStringLength/count {
+				return nil, fmt.Errorf("Result of %s would exceed %d bytes.", name, maxStringLength)
+			}
+
+			return &StrObject{Value: strings.Repeat(s, count)}, nil
+		}
+	default:
+		return nil, false
+	}
+
+	return &NativeFunction{
+		Name:   name,
+		Params: params,
+		Fn:     func(_ *Evaluator, args []Object) (Object, error) { return fn(args) },
+	}, true
+}
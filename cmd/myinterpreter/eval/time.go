@@ -0,0 +1,249 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	_ "time/tzdata" // time zones work without a zoneinfo database on the host
+)
+
+// Clock returns the current time. The time natives read it instead of the
+// system clock, so tests can fix the time with WithClock.
+type Clock func() time.Time
+
+// WithClock makes clock() and now() report the time returned by clock.
+func WithClock(clock Clock) Option {
+	return func(e *Evaluator) {
+		e.clock = clock
+	}
+}
+
+func (e *Evaluator) timeNatives() []*NativeFunction {
+	clock := e.clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	return []*NativeFunction{
+		{Name: "clock", Fn: func(_ *Evaluator, _ []Object) (Object, error) {
+			return &NumObject{Value: float64(clock().UnixNano()) / float64(time.Second)}, nil
+		}},
+		{Name: "now", Fn: func(_ *Evaluator, _ []Object) (Object, error) {
+			return &TimeObject{Value: clock()}, nil
+		}},
+	}
+}
+
+// timeNamespace returns the time global. Layouts use the notation of Go's
+// time package, i.e. the reference time Mon Jan 2 15:04:05 MST 2006.
+func timeNamespace() *NamespaceObject {
+	natives := []*NativeFunction{
+		{Name: "parse", Params: []Parameter{{Name: "text"}, {Name: "layout"}, {Name: "zone", Optional: true}}, Fn: timeParse},
+		{Name: "duration", Params: []Parameter{{Name: "value"}}, Fn: timeDuration},
+	}
+
+	return newNamespace("time", natives, map[string]Object{
+		"RFC3339":  &StrObject{Value: time.RFC3339},
+		"DateTime": &StrObject{Value: time.DateTime},
+		"DateOnly": &StrObject{Value: time.DateOnly},
+		"TimeOnly": &StrObject{Value: time.TimeOnly},
+	})
+}
+
+func zoneArg(fn string, arg Object) (*time.Location, error) {
+	name, err := stringArg(fn, "zone", arg)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown time zone '%s'.", name)
+	}
+
+	return loc, nil
+}
+
+// timeParse parses text according to layout. Times without an offset are
+// taken to be in zone, or UTC if there is none.
+func timeParse(_ *Evaluator, args []Object) (Object, error) {
+	text, err := stringArg("parse", "text", args[0])
+	if err != nil {
+		return nil, err
+	}
+	layout, err := stringArg("parse", "layout", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if args[2] != nil {
+		if loc, err = zoneArg("parse", args[2]); err != nil {
+			return nil, err
+		}
+	}
+
+	t, err := time.ParseInLocation(layout, text, loc)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse '%s' with layout '%s'.", text, layout)
+	}
+
+	return &TimeObject{Value: t}, nil
+}
+
+// timeDuration creates a duration from a number of seconds or from a string
+// such as "1h30m".
+func timeDuration(_ *Evaluator, args []Object) (Object, error) {
+	if s, ok := args[0].(*StrObject); ok {
+		d, err := time.ParseDuration(s.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid duration '%s'.", s.Value)
+		}
+		return &DurationObject{Value: d}, nil
+	}
+
+	seconds, err := numberArg("duration", "value", args[0])
+	if err != nil {
+		return nil, errors.New("Argument 'value' of duration must be a number or a string.")
+	}
+
+	// float64(math.MaxInt64) rounds up to 2^63, so the upper bound is exclusive
+	nanos := seconds * float64(time.Second)
+	if math.IsNaN(nanos) || nanos >= math.MaxInt64 || nanos < math.MinInt64 {
+		return nil, fmt.Errorf("Duration of %v seconds is out of range.", args[0])
+	}
+
+	return &DurationObject{Value: time.Duration(nanos)}, nil
+}
+
+// TimeObject is an instant in time together with the zone it is shown in.
+type TimeObject struct {
+	Value time.Time
+}
+
+func (o *TimeObject) Type() string {
+	return "TIME_OBJ"
+}
+func (o *TimeObject) String() string {
+	return o.Value.Format(time.RFC3339Nano)
+}
+func (o *TimeObject) Get(name string) (Object, bool) {
+	t := o.Value
+
+	switch name {
+	case "year":
+		return &NumObject{Value: float64(t.Year())}, true
+	case "month":
+		return &NumObject{Value: float64(t.Month())}, true
+	case "day":
+		return &NumObject{Value: float64(t.Day())}, true
+	case "hour":
+		return &NumObject{Value: float64(t.Hour())}, true
+	case "minute":
+		return &NumObject{Value: float64(t.Minute())}, true
+	case "second":
+		return &NumObject{Value: float64(t.Second())}, true
+	case "weekday":
+		return &StrObject{Value: t.Weekday().String()}, true
+	case "zone":
+		return &StrObject{Value: t.Location().String()}, true
+	case "unix":
+		return &NumObject{Value: float64(t.UnixNano()) / float64(time.Second)}, true
+	case "format":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "layout"}}, Fn: o.format}, true
+	case "in":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "zone"}}, Fn: o.in}, true
+	case "add":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "duration"}}, Fn: o.add}, true
+	case "since":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "time"}}, Fn: o.since}, true
+	case "before", "after":
+		return &NativeFunction{Name: name, Params: []Parameter{{Name: "time"}}, Fn: o.compare(name)}, true
+	}
+
+	return nil, false
+}
+
+func timeArg(fn string, arg Object) (time.Time, error) {
+	if t, ok := arg.(*TimeObject); ok {
+		return t.Value, nil
+	}
+
+	return time.Time{}, fmt.Errorf("Argument 'time' of %s must be a time.", fn)
+}
+
+func (o *TimeObject) format(_ *Evaluator, args []Object) (Object, error) {
+	layout, err := stringArg("format", "layout", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &StrObject{Value: o.Value.Format(layout)}, nil
+}
+
+// in returns the same instant shown in another time zone.
+func (o *TimeObject) in(_ *Evaluator, args []Object) (Object, error) {
+	loc, err := zoneArg("in", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimeObject{Value: o.Value.In(loc)}, nil
+}
+func (o *TimeObject) add(_ *Evaluator, args []Object) (Object, error) {
+	d, ok := args[0].(*DurationObject)
+	if !ok {
+		return nil, errors.New("Argument 'duration' of add must be a duration.")
+	}
+
+	return &TimeObject{Value: o.Value.Add(d.Value)}, nil
+}
+
+// since returns the duration from time to this time.
+func (o *TimeObject) since(_ *Evaluator, args []Object) (Object, error) {
+	t, err := timeArg("since", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &DurationObject{Value: o.Value.Sub(t)}, nil
+}
+func (o *TimeObject) compare(fn string) func(*Evaluator, []Object) (Object, error) {
+	return func(_ *Evaluator, args []Object) (Object, error) {
+		t, err := timeArg(fn, args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if fn == "before" {
+			return &BooleanObject{Value: o.Value.Before(t)}, nil
+		}
+		return &BooleanObject{Value: o.Value.After(t)}, nil
+	}
+}
+
+type DurationObject struct {
+	Value time.Duration
+}
+
+func (o *DurationObject) Type() string {
+	return "DURATION_OBJ"
+}
+func (o *DurationObject) String() string {
+	return o.Value.String()
+}
+func (o *DurationObject) Get(name string) (Object, bool) {
+	switch name {
+	case "hours":
+		return &NumObject{Value: o.Value.Hours()}, true
+	case "minutes":
+		return &NumObject{Value: o.Value.Minutes()}, true
+	case "seconds":
+		return &NumObject{Value: o.Value.Seconds()}, true
+	case "milliseconds":
+		return &NumObject{Value: float64(o.Value.Milliseconds())}, true
+	}
+
+	return nil, false
+}
@@ -18,12 +18,23 @@ func (t TokenType) String() string {
 		"RIGHT_PAREN",
 		"LEFT_BRACE",
 		"RIGHT_BRACE",
+		"LEFT_BRACKET",
+		"RIGHT_BRACKET",
 		"PLUS",
 		"MINUS",
 		"STAR",
+		"PLUS_EQUAL",
+		"MINUS_EQUAL",
+		"STAR_EQUAL",
+		"SLASH_EQUAL",
+		"PLUS_PLUS",
+		"MINUS_MINUS",
 		"DOT",
+		"ELLIPSIS",
 		"COMMA",
 		"SEMICOLON",
+		"PIPE",
+		"COLON",
 		"EQUAL",
 		"BANG",
 		"BANG_EQUAL",
@@ -33,8 +44,12 @@ func (t TokenType) String() string {
 		"LESS_EQUAL",
 		"GREATER",
 		"GREATER_EQUAL",
+		"ARROW",
 		"STRING",
+		"INTERPOLATION",
 		"NUMBER",
+		"BIGINT",
+		"DECIMAL",
 		"IDENTIFIER",
 		"AND",
 		"CLASS",
@@ -43,10 +58,12 @@ func (t TokenType) String() string {
 		"FOR",
 		"FUN",
 		"IF",
+		"MATCH",
 		"NIL",
 		"OR",
 		"PRINT",
 		"RETURN",
+		"SPAWN",
 		"SUPER",
 		"THIS",
 		"TRUE",
@@ -63,12 +80,23 @@ const (
 	RIGHT_PAREN
 	LEFT_BRACE
 	RIGHT_BRACE
+	LEFT_BRACKET
+	RIGHT_BRACKET
 	PLUS
 	MINUS
 	STAR
+	PLUS_EQUAL
+	MINUS_EQUAL
+	STAR_EQUAL
+	SLASH_EQUAL
+	PLUS_PLUS
+	MINUS_MINUS
 	DOT
+	ELLIPSIS
 	COMMA
 	SEMICOLON
+	PIPE
+	COLON
 	EQUAL
 	BANG
 	BANG_EQUAL
@@ -78,8 +106,12 @@ const (
 	LESS_EQUAL
 	GREATER
 	GREATER_EQUAL
+	ARROW
 	STRING
+	INTERPOLATION
 	NUMBER
+	BIGINT
+	DECIMAL
 	IDENTIFIER
 	AND
 	CLASS
@@ -88,10 +120,12 @@ const (
 	FOR
 	FUN
 	IF
+	MATCH
 	NIL
 	OR
 	PRINT
 	RETURN
+	SPAWN
 	SUPER
 	THIS
 	TRUE
@@ -112,6 +146,10 @@ func tokenType(c string) TokenType {
 		return LEFT_BRACE
 	case "}":
 		return RIGHT_BRACE
+	case "[":
+		return LEFT_BRACKET
+	case "]":
+		return RIGHT_BRACKET
 	case "+":
 		return PLUS
 	case "-":
@@ -124,6 +162,10 @@ func tokenType(c string) TokenType {
 		return COMMA
 	case ";":
 		return SEMICOLON
+	case "|":
+		return PIPE
+	case ":":
+		return COLON
 	case "=":
 		return EQUAL
 	case "!":
@@ -134,6 +176,18 @@ func tokenType(c string) TokenType {
 		return EQUAL_EQUAL
 	case "*":
 		return STAR
+	case "+=":
+		return PLUS_EQUAL
+	case "-=":
+		return MINUS_EQUAL
+	case "*=":
+		return STAR_EQUAL
+	case "/=":
+		return SLASH_EQUAL
+	case "++":
+		return PLUS_PLUS
+	case "--":
+		return MINUS_MINUS
 	case "<":
 		return LESS
 	case "<=":
@@ -142,6 +196,8 @@ func tokenType(c string) TokenType {
 		return GREATER
 	case ">=":
 		return GREATER_EQUAL
+	case "=>":
+		return ARROW
 	case "EOF":
 		return EOF
 	}
@@ -157,10 +213,12 @@ var keywordToTokenType = map[string]TokenType{
 	"for":    FOR,
 	"fun":    FUN,
 	"if":     IF,
+	"match":  MATCH,
 	"nil":    NIL,
 	"or":     OR,
 	"print":  PRINT,
 	"return": RETURN,
+	"spawn":  SPAWN,
 	"super":  SUPER,
 	"this":   THIS,
 	"true":   TRUE,
@@ -168,6 +226,12 @@ var keywordToTokenType = map[string]TokenType{
 	"while":  WHILE,
 }
 
+// IsKeyword reports whether lexeme is a reserved word.
+func IsKeyword(lexeme string) bool {
+	_, ok := keywordToTokenType[lexeme]
+	return ok
+}
+
 type Token struct {
 	Type    TokenType
 	Lexeme  string
@@ -182,6 +246,10 @@ type Lexer struct {
 	currPos  int
 	readPos  int
 	char     rune
+
+	// interpolations holds the depth of nested braces for every '${' that
+	// is still open, so the '}' closing it can be told apart from others
+	interpolations []int
 }
 
 func NewLexer(input []byte) *Lexer {
@@ -200,8 +268,40 @@ func (l *Lexer) NextToken() Token {
 	l.skipWhitespaces()
 
 	switch l.char {
-	case '(', ')', '{', '}', '+', '-', '*', '.', ',', ';':
+	case '(', ')', '[', ']', ',', ';', '|', ':':
 		token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
+	case '.':
+		if l.peek() == '.' && l.peekNext() == '.' {
+			l.readChar()
+			l.readChar()
+			token = Token{Type: ELLIPSIS, Lexeme: "...", Line: l.currLine}
+		} else {
+			token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
+		}
+	case '+', '-', '*':
+		if l.peek() == '=' || l.char != '*' && l.peek() == l.char {
+			ch := l.char
+			l.readChar()
+			lex := string(ch) + string(l.char)
+			token = Token{Type: tokenType(lex), Lexeme: lex, Line: l.currLine}
+		} else {
+			token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
+		}
+	case '{':
+		if n := len(l.interpolations); n > 0 {
+			l.interpolations[n-1]++
+		}
+		token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
+	case '}':
+		if n := len(l.interpolations); n > 0 && l.interpolations[n-1] == 0 {
+			l.interpolations = l.interpolations[:n-1]
+			token = l.readStringToken() // the rest of the interpolated string
+		} else {
+			if n > 0 {
+				l.interpolations[n-1]--
+			}
+			token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
+		}
 	case '/':
 		if l.peek() == '/' {
 			for l.char != '\n' && l.char != '\r' && l.char != 0 {
@@ -211,11 +311,17 @@ func (l *Lexer) NextToken() Token {
 				l.currLine++
 			}
 			token = Token{Type: COMMENT}
+		} else if l.peek() == '=' {
+			l.readChar()
+			token = Token{Type: SLASH_EQUAL, Lexeme: "/=", Line: l.currLine}
 		} else {
 			token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
 		}
 	case '!', '=', '<', '>':
-		if l.peek() == '=' {
+		if l.char == '=' && l.peek() == '>' {
+			l.readChar()
+			token = Token{Type: ARROW, Lexeme: "=>", Line: l.currLine}
+		} else if l.peek() == '=' {
 			ch := l.char
 			l.readChar()
 			lex := string(ch) + string(l.char)
@@ -224,19 +330,29 @@ func (l *Lexer) NextToken() Token {
 			token = Token{Type: tokenType(string(l.char)), Lexeme: string(l.char), Line: l.currLine}
 		}
 	case '"':
-		str := l.readString()
-		if l.char == 0 { // EOF
+		token = l.readStringToken()
+	case 0:
+		// a string whose '${' was never closed ends with the input
+		if len(l.interpolations) > 0 {
+			l.interpolations = nil
 			l.Errors = append(l.Errors, fmt.Errorf("[line %d] %w Unterminated string.", l.currLine, LexerError))
-			token = Token{Type: ERROR, Lexeme: string(l.char)}
-		} else {
-			token = Token{Type: STRING, Lexeme: `"` + str + `"`, Literal: str, Line: l.currLine}
 		}
-	case 0:
 		token = Token{Type: tokenType("EOF")}
 	default:
 		if unicode.IsDigit(l.char) {
 			number := l.readNumber()
-			token = Token{Type: NUMBER, Lexeme: number, Literal: trailZeroes(number), Line: l.currLine}
+			switch number[len(number)-1] {
+			case 'n':
+				if strings.Contains(number, ".") {
+					l.Errors = append(l.Errors, fmt.Errorf("[line %d] %w Invalid integer literal: %s", l.currLine, LexerError, number))
+					return Token{Type: ERROR, Lexeme: number, Line: l.currLine}
+				}
+				token = Token{Type: BIGINT, Lexeme: number, Literal: number[:len(number)-1], Line: l.currLine}
+			case 'd':
+				token = Token{Type: DECIMAL, Lexeme: number, Literal: number[:len(number)-1], Line: l.currLine}
+			default:
+				token = Token{Type: NUMBER, Lexeme: number, Literal: trailZeroes(number), Line: l.currLine}
+			}
 			return token
 		} else if isAlphaNumeric(l.char) {
 			ident := l.readIdentifier()
@@ -278,6 +394,13 @@ func (l *Lexer) peek() rune {
 
 	return rune(l.input[l.readPos])
 }
+func (l *Lexer) peekNext() rune {
+	if l.readPos+1 >= len(l.input) {
+		return 0
+	}
+
+	return rune(l.input[l.readPos+1])
+}
 func (l *Lexer) skipWhitespaces() {
 	for l.char == ' ' || l.char == '\t' || l.char == '\n' || l.char == '\r' {
 		if l.char == '\n' || l.char == '\r' {
@@ -299,7 +422,7 @@ func (l *Lexer) readString() string {
 	startPos := l.currPos + 1
 	for {
 		l.readChar()
-		if l.char == 0 || l.char == '"' {
+		if l.char == 0 || l.char == '"' || l.char == '$' && l.peek() == '{' {
 			break
 		}
 	}
@@ -307,6 +430,27 @@ func (l *Lexer) readString() string {
 
 	return str
 }
+
+// readStringToken reads a string starting at the current '"', or at the '}'
+// that closes an interpolated expression. A string interrupted by '${' is
+// returned as an INTERPOLATION token and lexing continues with the embedded
+// expression; the part after the last '}' is a regular STRING token.
+func (l *Lexer) readStringToken() Token {
+	startPos := l.currPos
+	str := l.readString()
+
+	switch l.char {
+	case 0: // EOF
+		l.Errors = append(l.Errors, fmt.Errorf("[line %d] %w Unterminated string.", l.currLine, LexerError))
+		return Token{Type: ERROR, Lexeme: string(l.char)}
+	case '$':
+		l.readChar() // advance to '{'
+		l.interpolations = append(l.interpolations, 0)
+		return Token{Type: INTERPOLATION, Lexeme: string(l.input[startPos:l.readPos]), Literal: str, Line: l.currLine}
+	}
+
+	return Token{Type: STRING, Lexeme: string(l.input[startPos:l.readPos]), Literal: str, Line: l.currLine}
+}
 func (l *Lexer) readNumber() string {
 	startPos := l.currPos
 	for unicode.IsDigit(l.char) {
@@ -321,6 +465,12 @@ func (l *Lexer) readNumber() string {
 		}
 	}
 
+	// 'n' marks a big integer (123n), 'd' an exact decimal (1.10d); a fraction
+	// with 'n' is consumed as well so it can be reported as invalid
+	if !isAlphaNumeric(l.peek()) && (l.char == 'd' || l.char == 'n') {
+		l.readChar() // consume suffix
+	}
+
 	return string(l.input[startPos:l.currPos])
 }
 func (l *Lexer) readIdentifier() string {
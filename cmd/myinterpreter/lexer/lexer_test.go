@@ -24,6 +24,47 @@ func TestLexer_Tokens(t *testing.T) {
 			{Type: NUMBER, Lexeme: "4", Literal: "4.0", Line: 1},
 			{Type: EOF},
 		}},
+		{"scanExactNumbers", args{"12n 1.10d 3.5n"}, []Token{
+			{Type: BIGINT, Lexeme: "12n", Literal: "12", Line: 1},
+			{Type: DECIMAL, Lexeme: "1.10d", Literal: "1.10", Line: 1},
+			{Type: EOF},
+		}},
+		{"scanInterpolation", args{"\"a ${ {b} } c\""}, []Token{
+			{Type: INTERPOLATION, Lexeme: "\"a ${", Literal: "a ", Line: 1},
+			{Type: LEFT_BRACE, Lexeme: "{", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "b", Line: 1},
+			{Type: RIGHT_BRACE, Lexeme: "}", Line: 1},
+			{Type: STRING, Lexeme: "} c\"", Literal: " c", Line: 1},
+			{Type: EOF},
+		}},
+		{"scanCompoundOperators", args{"a+=1--/=b++"}, []Token{
+			{Type: IDENTIFIER, Lexeme: "a", Line: 1},
+			{Type: PLUS_EQUAL, Lexeme: "+=", Line: 1},
+			{Type: NUMBER, Lexeme: "1", Literal: "1.0", Line: 1},
+			{Type: MINUS_MINUS, Lexeme: "--", Line: 1},
+			{Type: SLASH_EQUAL, Lexeme: "/=", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "b", Line: 1},
+			{Type: PLUS_PLUS, Lexeme: "++", Line: 1},
+			{Type: EOF},
+		}},
+		{"scanEllipsis", args{"(...a).b"}, []Token{
+			{Type: LEFT_PAREN, Lexeme: "(", Line: 1},
+			{Type: ELLIPSIS, Lexeme: "...", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "a", Line: 1},
+			{Type: RIGHT_PAREN, Lexeme: ")", Line: 1},
+			{Type: DOT, Lexeme: ".", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "b", Line: 1},
+			{Type: EOF},
+		}},
+		{"scanBrackets", args{"[a, ...b]"}, []Token{
+			{Type: LEFT_BRACKET, Lexeme: "[", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "a", Line: 1},
+			{Type: COMMA, Lexeme: ",", Line: 1},
+			{Type: ELLIPSIS, Lexeme: "...", Line: 1},
+			{Type: IDENTIFIER, Lexeme: "b", Line: 1},
+			{Type: RIGHT_BRACKET, Lexeme: "]", Line: 1},
+			{Type: EOF},
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -61,3 +102,24 @@ func prepareTmpFile(t *testing.T, content string) ([]byte, *os.File) {
 
 	return str, f
 }
+
+func TestLexer_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"fractionalBigInt", "1 + 3.5n", "[line 1] Error: Invalid integer literal: 3.5n"},
+		{"unterminatedInterpolation", "\"a ${ b", "[line 1] Error: Unterminated string."},
+		{"unexpectedCharacter", "1 # 2", "[line 1] Error: Unexpected character: #"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLexer([]byte(tt.input))
+			l.Tokens()
+			if len(l.Errors) != 1 || l.Errors[0].Error() != tt.want {
+				t.Errorf("Errors = %v, want %v", l.Errors, tt.want)
+			}
+		})
+	}
+}
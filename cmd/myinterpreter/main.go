@@ -3,10 +3,12 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/eval"
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/parser"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/typecheck"
 )
 
 func main() {
@@ -16,12 +18,33 @@ func main() {
 	}
 
 	command := os.Args[1]
-	if command != "tokenize" && command != "parse" && command != "evaluate" {
+	if command != "tokenize" && command != "parse" && command != "evaluate" && command != "typecheck" {
 		_, _ = fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
 		os.Exit(1)
 	}
 
 	filename := os.Args[2]
+
+	// --allow-fs gives scripts access to the working directory, or to the
+	// directory given with --allow-fs=<dir>.
+	var opts []eval.Option
+	for _, arg := range os.Args[3:] {
+		switch {
+		case arg == "--allow-fs":
+			opts = append(opts, eval.WithFileSystem("."))
+		case strings.HasPrefix(arg, "--allow-fs="):
+			root := strings.TrimPrefix(arg, "--allow-fs=")
+			if root == "" {
+				_, _ = fmt.Fprintln(os.Stderr, "Flag --allow-fs= needs a directory")
+				os.Exit(1)
+			}
+			opts = append(opts, eval.WithFileSystem(root))
+		default:
+			_, _ = fmt.Fprintf(os.Stderr, "Unknown flag: %s\n", arg)
+			os.Exit(1)
+		}
+	}
+
 	fileContents, err := os.ReadFile(filename)
 	if err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
@@ -43,6 +66,7 @@ func main() {
 		// Parse
 		p := parser.NewParser(l)
 		ast := p.ParseExpr(parser.LOWEST)
+		parser.PrintWarnings(p.Warnings)
 		if len(p.Errors) > 0 {
 			code := parser.CheckErrors(p.Errors)
 			os.Exit(code)
@@ -57,14 +81,16 @@ func main() {
 		// Parse
 		p := parser.NewParser(l)
 		ast := p.ParseExpr(parser.LOWEST)
+		parser.PrintWarnings(p.Warnings)
 		if len(p.Errors) > 0 {
 			code := parser.CheckErrors(p.Errors)
 			os.Exit(code)
 		}
 
 		// Evaluate
-		e := eval.Evaluator{}
+		e := eval.NewEvaluator(opts...)
 		obj := e.Eval(ast)
+		e.Wait()
 		if len(e.Errors) > 0 {
 			code := eval.CheckErrors(e.Errors)
 			os.Exit(code)
@@ -72,5 +98,28 @@ func main() {
 
 		// Print
 		fmt.Println(obj)
+	} else if command == "typecheck" {
+		// Tokenize
+		l := lexer.NewLexer(fileContents)
+
+		// Parse
+		p := parser.NewParser(l)
+		ast := p.ParseExpr(parser.LOWEST)
+		parser.PrintWarnings(p.Warnings)
+		if len(p.Errors) > 0 {
+			code := parser.CheckErrors(p.Errors)
+			os.Exit(code)
+		}
+
+		// Check
+		c := typecheck.NewChecker()
+		t := c.Check(ast)
+		if len(c.Errors) > 0 {
+			code := typecheck.CheckErrors(c.Errors)
+			os.Exit(code)
+		}
+
+		// Print
+		fmt.Println(t)
 	}
 }
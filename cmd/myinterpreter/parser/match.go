@@ -0,0 +1,260 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
+)
+
+func (p *Parser) parseMatchExpr() ast.Node {
+	expr := ast.MatchExpr{
+		Token: p.currToken,
+	}
+	p.nextToken() // consume 'match'
+
+	expr.Subject = p.ParseExpr(LOWEST)
+	if !p.expectPeek(lexer.LEFT_BRACE, "Expect '{' after match value.") {
+		return nil
+	}
+
+	for p.peekToken.Type != lexer.RIGHT_BRACE && p.peekToken.Type != lexer.EOF {
+		p.nextToken() // advance to a pattern
+
+		arm, ok := p.parseMatchArm()
+		if !ok {
+			return nil
+		}
+		expr.Arms = append(expr.Arms, arm)
+
+		if p.peekToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // advance to ','
+	}
+
+	if !p.expectPeek(lexer.RIGHT_BRACE, "Expect '}' after match arms.") {
+		return nil
+	}
+	p.checkReachability(expr)
+
+	return expr
+}
+func (p *Parser) parseMatchArm() (ast.MatchArm, bool) {
+	arm := ast.MatchArm{
+		Token: p.currToken,
+	}
+
+	arm.Pattern = p.parsePattern()
+	if arm.Pattern == nil {
+		return arm, false
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range patternNames(arm.Pattern) {
+		if seen[name.Value] {
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Already a variable with this name in this pattern.", name.Token.Line, name.Value,
+			))
+			return arm, false
+		}
+		seen[name.Value] = true
+	}
+
+	if p.peekToken.Type == lexer.IF {
+		p.nextToken() // advance to 'if'
+		p.nextToken() // consume 'if'
+
+		p.noArrow = true
+		arm.Guard = p.ParseExpr(LOWEST)
+		p.noArrow = false
+	}
+
+	if !p.expectPeek(lexer.ARROW, "Expect '=>' after pattern.") {
+		return arm, false
+	}
+	p.nextToken() // consume '=>'
+
+	arm.Body = p.ParseExpr(LOWEST)
+
+	return arm, true
+}
+func (p *Parser) parsePattern() ast.Pattern {
+	pattern := p.parseSinglePattern()
+	if pattern == nil || p.peekToken.Type != lexer.PIPE {
+		return pattern
+	}
+
+	alt := ast.AlternativePattern{
+		Alternatives: []ast.Pattern{pattern},
+	}
+	for p.peekToken.Type == lexer.PIPE {
+		p.nextToken() // advance to '|'
+		p.nextToken() // consume '|'
+
+		pattern = p.parseSinglePattern()
+		if pattern == nil {
+			return nil
+		}
+		alt.Alternatives = append(alt.Alternatives, pattern)
+	}
+
+	for _, pattern := range alt.Alternatives {
+		if names := patternNames(pattern); len(names) > 0 {
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Cannot bind a name in an alternative pattern.", names[0].Token.Line, names[0].Value,
+			))
+			return nil
+		}
+	}
+
+	return alt
+}
+func (p *Parser) parseSinglePattern() ast.Pattern {
+	switch p.currToken.Type {
+	case lexer.IDENTIFIER:
+		if p.currToken.Lexeme == "_" {
+			return ast.WildcardPattern{Token: p.currToken}
+		}
+		return ast.BindingPattern{Name: p.parseIdentifier().(ast.Identifier)}
+	case lexer.NUMBER, lexer.BIGINT, lexer.DECIMAL, lexer.STRING, lexer.TRUE, lexer.FALSE, lexer.NIL:
+		return ast.LiteralPattern{Value: p.prefixOps[p.currToken.Type]()}
+	case lexer.MINUS:
+		switch p.peekToken.Type {
+		case lexer.NUMBER, lexer.BIGINT, lexer.DECIMAL:
+			return ast.LiteralPattern{Value: p.parsePrefixExpr()}
+		}
+	case lexer.LEFT_BRACKET:
+		return p.parseListPattern()
+	}
+
+	p.Errors = append(p.Errors, fmt.Errorf(
+		"[line %d] Error at '%s': Expect pattern.", p.currToken.Line, p.currToken.Lexeme,
+	))
+
+	return nil
+}
+func (p *Parser) parseListPattern() ast.Pattern {
+	pattern := ast.ListPattern{
+		Token: p.currToken,
+	}
+
+	for p.peekToken.Type != lexer.RIGHT_BRACKET && p.peekToken.Type != lexer.EOF {
+		p.nextToken() // advance to an element
+
+		if p.currToken.Type == lexer.ELLIPSIS {
+			if !p.expectPeek(lexer.IDENTIFIER, "Expect name after '...'.") {
+				return nil
+			}
+			pattern.Rest = p.parseSinglePattern()
+			break // the rest has to be the last element
+		}
+
+		elem := p.parsePattern()
+		if elem == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, elem)
+
+		if p.peekToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // advance to ','
+	}
+
+	if !p.expectPeek(lexer.RIGHT_BRACKET, "Expect ']' after list pattern.") {
+		return nil
+	}
+
+	return pattern
+}
+
+// patternNames returns the names bound by the pattern.
+func patternNames(pattern ast.Pattern) []ast.Identifier {
+	switch pattern := pattern.(type) {
+	case ast.BindingPattern:
+		return []ast.Identifier{pattern.Name}
+	case ast.AlternativePattern:
+		var names []ast.Identifier
+		for _, alt := range pattern.Alternatives {
+			names = append(names, patternNames(alt)...)
+		}
+		return names
+	case ast.ListPattern:
+		var names []ast.Identifier
+		for _, elem := range pattern.Elements {
+			names = append(names, patternNames(elem)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, patternNames(pattern.Rest)...)
+		}
+		return names
+	}
+
+	return nil
+}
+
+// checkReachability warns about arms that can never be selected: arms after
+// an unguarded catch-all, and literal arms whose values were all matched by
+// earlier unguarded arms.
+func (p *Parser) checkReachability(expr ast.MatchExpr) {
+	catchAll := false
+	seen := make(map[string]bool)
+
+	for _, arm := range expr.Arms {
+		literals, irrefutable := patternLiterals(arm.Pattern)
+
+		unreachable := catchAll
+		if !unreachable && !irrefutable && len(literals) > 0 {
+			unreachable = true
+			for _, lit := range literals {
+				if !seen[lit] {
+					unreachable = false
+				}
+			}
+		}
+
+		if unreachable {
+			p.Warnings = append(p.Warnings, fmt.Errorf(
+				"[line %d] Warning at '%s': Unreachable match arm.", arm.Token.Line, arm.Token.Lexeme,
+			))
+		}
+
+		if arm.Guard != nil {
+			continue
+		}
+		catchAll = catchAll || irrefutable
+		for _, lit := range literals {
+			seen[lit] = true
+		}
+	}
+}
+
+// patternLiterals returns a key for every literal the pattern matches and
+// whether the pattern matches any value at all. Patterns that match other
+// values too, like list patterns, return no literals.
+func patternLiterals(pattern ast.Pattern) ([]string, bool) {
+	switch pattern := pattern.(type) {
+	case ast.WildcardPattern, ast.BindingPattern:
+		return nil, true
+	case ast.LiteralPattern:
+		return []string{pattern.Value.Type() + " " + pattern.Value.String()}, false
+	case ast.AlternativePattern:
+		var literals []string
+		other := false
+		for _, alt := range pattern.Alternatives {
+			lits, irrefutable := patternLiterals(alt)
+			if irrefutable {
+				return nil, true
+			}
+			other = other || lits == nil
+			literals = append(literals, lits...)
+		}
+		if other {
+			return nil, false
+		}
+		return literals, false
+	}
+
+	return nil, false
+}
@@ -2,8 +2,10 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
 	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
@@ -14,11 +16,13 @@ type infixFunc func(left ast.Node) ast.Node
 
 const (
 	LOWEST = iota // LOWEST is the universal binding power
+	ASSIGNMENT
 	EQUALITY
 	COMPARISON
 	ADDITIVE
 	MULTIPLICATIVE
 	PREFIX
+	POSTFIX
 	PAREN
 )
 
@@ -34,14 +38,26 @@ var tokenTypeToBp = map[lexer.TokenType]int{
 	lexer.STAR:          MULTIPLICATIVE,
 	lexer.SLASH:         MULTIPLICATIVE,
 	lexer.LEFT_PAREN:    PAREN,
+	lexer.DOT:           PAREN,
+	lexer.PLUS_EQUAL:    ASSIGNMENT,
+	lexer.MINUS_EQUAL:   ASSIGNMENT,
+	lexer.STAR_EQUAL:    ASSIGNMENT,
+	lexer.SLASH_EQUAL:   ASSIGNMENT,
+	lexer.PLUS_PLUS:     POSTFIX,
+	lexer.MINUS_MINUS:   POSTFIX,
 }
 
 type Parser struct {
 	Errors    []error
+	Warnings  []error
 	lexer     *lexer.Lexer
 	currToken lexer.Token
 	peekToken lexer.Token
 
+	// noArrow is set while parsing a match guard, where '(x) =>' ends the
+	// guard instead of starting a function
+	noArrow bool
+
 	prefixOps map[lexer.TokenType]prefixFunc
 	infixOps  map[lexer.TokenType]infixFunc
 }
@@ -58,10 +74,18 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.prefixOps[lexer.FALSE] = p.parseBool
 	p.prefixOps[lexer.NIL] = p.parseNil
 	p.prefixOps[lexer.NUMBER] = p.parseNum
+	p.prefixOps[lexer.BIGINT] = p.parseBigInt
+	p.prefixOps[lexer.DECIMAL] = p.parseDecimal
+	p.prefixOps[lexer.IDENTIFIER] = p.parseIdentifier
 	p.prefixOps[lexer.STRING] = p.parseString
+	p.prefixOps[lexer.INTERPOLATION] = p.parseInterpolation
 	p.prefixOps[lexer.LEFT_PAREN] = p.parseGroupedExpr
 	p.prefixOps[lexer.MINUS] = p.parsePrefixExpr
 	p.prefixOps[lexer.BANG] = p.parsePrefixExpr
+	p.prefixOps[lexer.MATCH] = p.parseMatchExpr
+	p.prefixOps[lexer.SPAWN] = p.parseSpawnExpr
+	p.prefixOps[lexer.PLUS_PLUS] = p.parsePrefixUpdateExpr
+	p.prefixOps[lexer.MINUS_MINUS] = p.parsePrefixUpdateExpr
 
 	// Infix
 	p.infixOps[lexer.MINUS] = p.parseInfixExpr
@@ -74,6 +98,14 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.infixOps[lexer.LESS_EQUAL] = p.parseInfixExpr
 	p.infixOps[lexer.BANG_EQUAL] = p.parseInfixExpr
 	p.infixOps[lexer.EQUAL_EQUAL] = p.parseInfixExpr
+	p.infixOps[lexer.LEFT_PAREN] = p.parseCallExpr
+	p.infixOps[lexer.DOT] = p.parseGetExpr
+	p.infixOps[lexer.PLUS_EQUAL] = p.parseCompoundAssignExpr
+	p.infixOps[lexer.MINUS_EQUAL] = p.parseCompoundAssignExpr
+	p.infixOps[lexer.STAR_EQUAL] = p.parseCompoundAssignExpr
+	p.infixOps[lexer.SLASH_EQUAL] = p.parseCompoundAssignExpr
+	p.infixOps[lexer.PLUS_PLUS] = p.parsePostfixUpdateExpr
+	p.infixOps[lexer.MINUS_MINUS] = p.parsePostfixUpdateExpr
 
 	// init currToken and peekToken
 	p.nextToken()
@@ -150,19 +182,114 @@ func (p *Parser) parseNum() ast.Node {
 		Value: num,
 	}
 }
+func (p *Parser) parseBigInt() ast.Node {
+	num, ok := new(big.Int).SetString(p.currToken.Literal, 10)
+	if !ok {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at '%s': Invalid integer literal.", p.currToken.Line, p.currToken.Lexeme,
+		))
+	}
+
+	return ast.BigIntLiteral{
+		Token: p.currToken,
+		Value: num,
+	}
+}
+func (p *Parser) parseDecimal() ast.Node {
+	num, ok := new(big.Rat).SetString(p.currToken.Literal)
+	if !ok {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at '%s': Invalid decimal literal.", p.currToken.Line, p.currToken.Lexeme,
+		))
+	}
+
+	return ast.DecimalLiteral{
+		Token: p.currToken,
+		Value: num,
+	}
+}
 func (p *Parser) parseString() ast.Node {
 	return ast.StringLiteral{
 		Token: p.currToken,
 		Value: p.currToken.Literal,
 	}
 }
+func (p *Parser) parseInterpolation() ast.Node {
+	expr := ast.InterpolationExpr{
+		Token: p.currToken,
+	}
+
+	for p.currToken.Type == lexer.INTERPOLATION {
+		if p.currToken.Literal != "" {
+			expr.Parts = append(expr.Parts, ast.StringLiteral{Token: p.currToken, Value: p.currToken.Literal})
+		}
+
+		// the string after '}' starts with the brace itself, so "${}" can be
+		// told apart from an embedded string literal
+		if strings.HasPrefix(p.peekToken.Lexeme, "}") {
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '}': Expect expression.", p.peekToken.Line,
+			))
+			return nil
+		}
+		p.nextToken() // consume '${'
+		expr.Parts = append(expr.Parts, p.ParseExpr(LOWEST))
+
+		p.nextToken() // advance to the rest of the string
+		if p.currToken.Type != lexer.STRING && p.currToken.Type != lexer.INTERPOLATION ||
+			!strings.HasPrefix(p.currToken.Lexeme, "}") {
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Expect '}' after interpolated expression.", p.currToken.Line, p.currToken.Lexeme,
+			))
+			return nil
+		}
+	}
+
+	if p.currToken.Literal != "" {
+		expr.Parts = append(expr.Parts, ast.StringLiteral{Token: p.currToken, Value: p.currToken.Literal})
+	}
+
+	return expr
+}
+func (p *Parser) parseIdentifier() ast.Node {
+	return ast.Identifier{
+		Token: p.currToken,
+		Value: p.currToken.Lexeme,
+	}
+}
 func (p *Parser) parseGroupedExpr() ast.Node {
 	expr := ast.GroupedExpr{
 		Token: p.currToken,
 	}
 	p.nextToken() // consume '('
 
-	exp := p.ParseExpr(0)
+	allowArrow := !p.noArrow
+	p.noArrow = false
+	defer func() { p.noArrow = !allowArrow }()
+
+	// '() =>' starts a function without parameters
+	if allowArrow && p.currToken.Type == lexer.RIGHT_PAREN && (p.peekToken.Type == lexer.ARROW || p.peekToken.Type == lexer.COLON) {
+		return p.parseArrowFunction(expr.Token, nil)
+	}
+
+	var items []groupItem
+	var paramOnly *lexer.Token
+	for {
+		item, ok := p.parseGroupItem()
+		if !ok {
+			return nil
+		}
+		if item.paramOnly && paramOnly == nil {
+			paramOnly = &item.token
+		}
+		items = append(items, item)
+
+		if p.peekToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // advance to ','
+		p.nextToken() // consume ','
+	}
 
 	if p.peekToken.Type != lexer.RIGHT_PAREN {
 		p.Errors = append(p.Errors, fmt.Errorf("Error: Unmatched parentheses."))
@@ -170,10 +297,146 @@ func (p *Parser) parseGroupedExpr() ast.Node {
 	} else {
 		p.nextToken() // consume ')'
 	}
-	expr.Value = exp
+
+	// a parenthesized list followed by '=>' or a return type is a parameter list
+	if allowArrow && (p.peekToken.Type == lexer.ARROW || p.peekToken.Type == lexer.COLON) {
+		return p.parseArrowFunction(expr.Token, items)
+	}
+
+	if paramOnly != nil {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at '%s': Expect '=>' after parameters.", p.peekToken.Line, p.peekToken.Lexeme,
+		))
+		return nil
+	}
+
+	if len(items) > 1 {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at ',': Expect ')' after expression.", expr.Token.Line,
+		))
+		return nil
+	}
+	expr.Value = items[0].expr
 
 	return expr
 }
+
+// groupItem is an element of a parenthesized list. It is a grouped expression
+// unless the list turns out to be the parameter list of an arrow function.
+type groupItem struct {
+	token     lexer.Token
+	expr      ast.Node
+	param     ast.Param // type, default value and rest marker of a parameter
+	paramOnly bool      // uses syntax that is only valid for parameters
+}
+
+func (p *Parser) parseGroupItem() (groupItem, bool) {
+	item := groupItem{
+		token: p.currToken,
+	}
+
+	if p.currToken.Type == lexer.ELLIPSIS {
+		if !p.expectPeek(lexer.IDENTIFIER, "Expect parameter name after '...'.") {
+			return item, false
+		}
+		item.param.Rest = true
+		item.paramOnly = true
+	}
+
+	item.expr = p.ParseExpr(0)
+
+	// 'name: Type' and 'name = default' can only be parameters
+	if p.peekToken.Type == lexer.COLON {
+		p.nextToken() // advance to ':'
+		item.param.Type = p.parseTypeAnnotation()
+		if item.param.Type == nil {
+			return item, false
+		}
+		item.paramOnly = true
+	}
+
+	if p.peekToken.Type == lexer.EQUAL {
+		p.nextToken() // advance to '='
+		p.nextToken() // consume '='
+		item.param.Default = p.ParseExpr(LOWEST)
+		item.paramOnly = true
+	}
+
+	return item, true
+}
+func (p *Parser) parseArrowFunction(tok lexer.Token, items []groupItem) ast.Node {
+	fn := ast.FunctionLiteral{
+		Token: tok,
+	}
+
+	for i, item := range items {
+		if item.expr == nil {
+			return nil
+		}
+
+		ident, ok := item.expr.(ast.Identifier)
+		if !ok {
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Expect parameter name.", item.token.Line, item.token.Lexeme,
+			))
+			return nil
+		}
+
+		for _, prev := range fn.Params {
+			if prev.Name.Value == ident.Value {
+				p.Errors = append(p.Errors, fmt.Errorf(
+					"[line %d] Error at '%s': Already a parameter with this name.", ident.Token.Line, ident.Value,
+				))
+			}
+		}
+
+		param := item.param
+		param.Name = ident
+		switch {
+		case param.Rest && param.Default != nil:
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Rest parameter cannot have a default value.", ident.Token.Line, ident.Value,
+			))
+		case param.Rest && i != len(items)-1:
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Rest parameter must be last.", ident.Token.Line, ident.Value,
+			))
+		case !param.Rest && param.Default == nil && i > 0 && fn.Params[i-1].Default != nil:
+			p.Errors = append(p.Errors, fmt.Errorf(
+				"[line %d] Error at '%s': Parameter without a default value cannot follow one with a default value.",
+				ident.Token.Line, ident.Value,
+			))
+		}
+		fn.Params = append(fn.Params, param)
+	}
+
+	if p.peekToken.Type == lexer.COLON {
+		p.nextToken() // advance to ':'
+		fn.ReturnType = p.parseTypeAnnotation()
+		if fn.ReturnType == nil {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(lexer.ARROW, "Expect '=>' after parameters.") {
+		return nil
+	}
+	p.nextToken() // consume '=>'
+
+	fn.Body = p.ParseExpr(LOWEST)
+
+	return fn
+}
+func (p *Parser) parseTypeAnnotation() *ast.TypeAnnotation {
+	if !p.expectPeek(lexer.IDENTIFIER, "Expect type name after ':'.") {
+		return nil
+	}
+
+	return &ast.TypeAnnotation{
+		Token: p.currToken,
+		Name:  p.currToken.Lexeme,
+	}
+}
 func (p *Parser) parsePrefixExpr() ast.Node {
 	expr := ast.PrefixExpr{
 		Token: p.currToken,
@@ -199,7 +462,166 @@ func (p *Parser) parseInfixExpr(left ast.Node) ast.Node {
 
 	return expr
 }
+func (p *Parser) parseCompoundAssignExpr(target ast.Node) ast.Node {
+	expr := ast.CompoundAssignExpr{
+		Token:  p.currToken,
+		Target: target,
+		Op:     strings.TrimSuffix(p.currToken.Lexeme, "="),
+	}
+	p.nextToken() // eat an operator token
+
+	expr.Value = p.ParseExpr(ASSIGNMENT - 1) // right-associative
+
+	if !p.checkAssignTarget(expr.Token, target) {
+		return nil
+	}
+
+	return expr
+}
+func (p *Parser) parsePrefixUpdateExpr() ast.Node {
+	expr := ast.UpdateExpr{
+		Token:  p.currToken,
+		Op:     p.currToken.Lexeme,
+		Prefix: true,
+	}
+	p.nextToken() // eat an operator token
+
+	expr.Target = p.ParseExpr(PREFIX)
+
+	if !p.checkAssignTarget(expr.Token, expr.Target) {
+		return nil
+	}
+
+	return expr
+}
+func (p *Parser) parsePostfixUpdateExpr(target ast.Node) ast.Node {
+	expr := ast.UpdateExpr{
+		Token:  p.currToken,
+		Target: target,
+		Op:     p.currToken.Lexeme,
+	}
+
+	if !p.checkAssignTarget(expr.Token, target) {
+		return nil
+	}
+
+	return expr
+}
+
+// checkAssignTarget reports whether target can be assigned to. Variables are
+// the only assignable expressions for now.
+func (p *Parser) checkAssignTarget(op lexer.Token, target ast.Node) bool {
+	if _, ok := target.(ast.Identifier); !ok {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at '%s': Invalid assignment target.", op.Line, op.Lexeme,
+		))
+		return false
+	}
+
+	return true
+}
+func (p *Parser) parseSpawnExpr() ast.Node {
+	expr := ast.SpawnExpr{
+		Token: p.currToken,
+	}
+	p.nextToken() // eat 'spawn'
 
+	call, ok := p.ParseExpr(PREFIX).(ast.CallExpr)
+	if !ok {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at 'spawn': Expect function call after 'spawn'.", expr.Token.Line,
+		))
+		return nil
+	}
+	expr.Call = call
+
+	return expr
+}
+func (p *Parser) parseGetExpr(object ast.Node) ast.Node {
+	expr := ast.GetExpr{
+		Token:  p.currToken,
+		Object: object,
+	}
+
+	// keywords are valid property names, e.g. re.match(s)
+	if lexer.IsKeyword(p.peekToken.Lexeme) {
+		p.peekToken.Type = lexer.IDENTIFIER
+	}
+	if !p.expectPeek(lexer.IDENTIFIER, "Expect property name after '.'.") {
+		return nil
+	}
+	expr.Name = p.parseIdentifier().(ast.Identifier)
+
+	return expr
+}
+func (p *Parser) parseCallExpr(callee ast.Node) ast.Node {
+	expr := ast.CallExpr{
+		Token:  p.currToken,
+		Callee: callee,
+	}
+
+	noArrow := p.noArrow
+	p.noArrow = false
+	defer func() { p.noArrow = noArrow }()
+
+	if p.peekToken.Type == lexer.RIGHT_PAREN {
+		p.nextToken() // consume ')'
+		return expr
+	}
+
+	for {
+		p.nextToken() // eat '(' or ','
+
+		// 'name: value' passes an argument by name
+		if p.currToken.Type == lexer.IDENTIFIER && p.peekToken.Type == lexer.COLON {
+			arg := ast.NamedArg{Name: p.parseIdentifier().(ast.Identifier)}
+			p.nextToken() // advance to ':'
+			p.nextToken() // consume ':'
+			arg.Value = p.ParseExpr(LOWEST)
+			expr.NamedArgs = append(expr.NamedArgs, arg)
+		} else {
+			if len(expr.NamedArgs) > 0 {
+				p.Errors = append(p.Errors, fmt.Errorf(
+					"[line %d] Error at '%s': Positional argument cannot follow named arguments.",
+					p.currToken.Line, p.currToken.Lexeme,
+				))
+				return nil
+			}
+			expr.Args = append(expr.Args, p.ParseExpr(LOWEST))
+		}
+
+		if p.peekToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // advance to ','
+	}
+
+	if !p.expectPeek(lexer.RIGHT_PAREN, "Expect ')' after arguments.") {
+		return nil
+	}
+
+	return expr
+}
+
+// expectPeek advances to the next token if it has type t, otherwise it records
+// msg as an error at the offending token.
+func (p *Parser) expectPeek(t lexer.TokenType, msg string) bool {
+	if p.peekToken.Type != t {
+		p.Errors = append(p.Errors, fmt.Errorf(
+			"[line %d] Error at '%s': %s", p.peekToken.Line, p.peekToken.Lexeme, msg,
+		))
+		return false
+	}
+	p.nextToken()
+
+	return true
+}
+
+func PrintWarnings(warnings []error) {
+	for _, w := range warnings {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", w)
+	}
+}
 func CheckErrors(errs []error) int {
 	for _, err := range errs {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"math/big"
 	"os"
 	"reflect"
 	"testing"
@@ -33,6 +34,18 @@ func TestParser_Parse(t *testing.T) {
 				Value: 42.47,
 			},
 		},
+		{"parseBigInt", args{0, "12n"},
+			ast.BigIntLiteral{
+				Token: lexer.Token{Type: lexer.BIGINT, Lexeme: "12n", Literal: "12", Line: 1},
+				Value: big.NewInt(12),
+			},
+		},
+		{"parseDecimal", args{0, "1.10d"},
+			ast.DecimalLiteral{
+				Token: lexer.Token{Type: lexer.DECIMAL, Lexeme: "1.10d", Literal: "1.10", Line: 1},
+				Value: big.NewRat(11, 10),
+			},
+		},
 		{"parseString", args{0, "\"hello\""},
 			ast.StringLiteral{
 				Token: lexer.Token{Type: lexer.STRING, Lexeme: "\"hello\"", Literal: "hello", Line: 1},
@@ -130,6 +143,243 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		},
+		{"parseCallExpr", args{0, "bigint(\"1\", x)"},
+			ast.CallExpr{
+				Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+				Callee: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "bigint", Line: 1},
+					Value: "bigint",
+				},
+				Args: []ast.Node{
+					ast.StringLiteral{
+						Token: lexer.Token{Type: lexer.STRING, Lexeme: "\"1\"", Literal: "1", Line: 1},
+						Value: "1",
+					},
+					ast.Identifier{
+						Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "x", Line: 1},
+						Value: "x",
+					},
+				},
+			},
+		},
+		{"parseArrowFunction", args{0, "(a, b) => a"},
+			ast.FunctionLiteral{
+				Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+				Params: []ast.Param{
+					{Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1}, Value: "a"}},
+					{Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "b", Line: 1}, Value: "b"}},
+				},
+				Body: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1},
+					Value: "a",
+				},
+			},
+		},
+		{"parseDefaultAndRestParams", args{0, "(a = 1, ...b) => a"},
+			ast.FunctionLiteral{
+				Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+				Params: []ast.Param{
+					{
+						Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1}, Value: "a"},
+						Default: ast.NumLiteral{
+							Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "1", Literal: "1.0", Line: 1},
+							Value: 1,
+						},
+					},
+					{Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "b", Line: 1}, Value: "b"}, Rest: true},
+				},
+				Body: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1},
+					Value: "a",
+				},
+			},
+		},
+		{"parseNamedArgs", args{0, "f(x, b: 2)"},
+			ast.CallExpr{
+				Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+				Callee: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "f", Line: 1},
+					Value: "f",
+				},
+				Args: []ast.Node{
+					ast.Identifier{
+						Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "x", Line: 1},
+						Value: "x",
+					},
+				},
+				NamedArgs: []ast.NamedArg{
+					{
+						Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "b", Line: 1}, Value: "b"},
+						Value: ast.NumLiteral{
+							Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "2", Literal: "2.0", Line: 1},
+							Value: 2,
+						},
+					},
+				},
+			},
+		},
+		{"parsePositionalAfterNamed", args{0, "f(b: 2, x)"}, nil},
+		{"parseAnnotatedArrowFunction", args{0, "(a: Number): Bool => a"},
+			ast.FunctionLiteral{
+				Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+				Params: []ast.Param{
+					{
+						Name: ast.Identifier{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1}, Value: "a"},
+						Type: &ast.TypeAnnotation{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "Number", Line: 1}, Name: "Number"},
+					},
+				},
+				ReturnType: &ast.TypeAnnotation{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "Bool", Line: 1}, Name: "Bool"},
+				Body: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1},
+					Value: "a",
+				},
+			},
+		},
+		{"parseAnnotationWithoutArrow", args{0, "(a: Number)"}, nil},
+		{"parseInterpolation", args{0, "\"a ${b}\""},
+			ast.InterpolationExpr{
+				Token: lexer.Token{Type: lexer.INTERPOLATION, Lexeme: "\"a ${", Literal: "a ", Line: 1},
+				Parts: []ast.Node{
+					ast.StringLiteral{
+						Token: lexer.Token{Type: lexer.INTERPOLATION, Lexeme: "\"a ${", Literal: "a ", Line: 1},
+						Value: "a ",
+					},
+					ast.Identifier{
+						Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "b", Line: 1},
+						Value: "b",
+					},
+				},
+			},
+		},
+		{"parseCompoundAssignExpr", args{0, "a -= b++"},
+			ast.CompoundAssignExpr{
+				Token: lexer.Token{Type: lexer.MINUS_EQUAL, Lexeme: "-=", Line: 1},
+				Target: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "a", Line: 1},
+					Value: "a",
+				},
+				Op: "-",
+				Value: ast.UpdateExpr{
+					Token: lexer.Token{Type: lexer.PLUS_PLUS, Lexeme: "++", Line: 1},
+					Target: ast.Identifier{
+						Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "b", Line: 1},
+						Value: "b",
+					},
+					Op: "++",
+				},
+			},
+		},
+		{"parseInvalidAssignTarget", args{0, "1 += 2"}, nil},
+		{"parseListPattern", args{0, "match x { [1, _, ...rest] => rest }"},
+			ast.MatchExpr{
+				Token: lexer.Token{Type: lexer.MATCH, Lexeme: "match", Line: 1},
+				Subject: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "x", Line: 1},
+					Value: "x",
+				},
+				Arms: []ast.MatchArm{
+					{
+						Token: lexer.Token{Type: lexer.LEFT_BRACKET, Lexeme: "[", Line: 1},
+						Pattern: ast.ListPattern{
+							Token: lexer.Token{Type: lexer.LEFT_BRACKET, Lexeme: "[", Line: 1},
+							Elements: []ast.Pattern{
+								ast.LiteralPattern{Value: ast.NumLiteral{
+									Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "1", Literal: "1.0", Line: 1},
+									Value: 1.,
+								}},
+								ast.WildcardPattern{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "_", Line: 1}},
+							},
+							Rest: ast.BindingPattern{Name: ast.Identifier{
+								Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "rest", Line: 1},
+								Value: "rest",
+							}},
+						},
+						Body: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "rest", Line: 1},
+							Value: "rest",
+						},
+					},
+				},
+			},
+		},
+		{"parseUnclosedListPattern", args{0, "match x { [a => a }"}, nil},
+		{"parseRestNotLast", args{0, "match x { [...a, b] => a }"}, nil},
+		{"parseDuplicateBinding", args{0, "match x { [a, a] => a }"}, nil},
+		{"parseBindingInAlternativeList", args{0, "match x { [a] | [] => 1 }"}, nil},
+		{"parseMatchExpr", args{0, "match x { 1 | _ => x, y if y => nil }"},
+			ast.MatchExpr{
+				Token: lexer.Token{Type: lexer.MATCH, Lexeme: "match", Line: 1},
+				Subject: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "x", Line: 1},
+					Value: "x",
+				},
+				Arms: []ast.MatchArm{
+					{
+						Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "1", Literal: "1.0", Line: 1},
+						Pattern: ast.AlternativePattern{Alternatives: []ast.Pattern{
+							ast.LiteralPattern{Value: ast.NumLiteral{
+								Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "1", Literal: "1.0", Line: 1},
+								Value: 1.,
+							}},
+							ast.WildcardPattern{Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "_", Line: 1}},
+						}},
+						Body: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "x", Line: 1},
+							Value: "x",
+						},
+					},
+					{
+						Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "y", Line: 1},
+						Pattern: ast.BindingPattern{Name: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "y", Line: 1},
+							Value: "y",
+						}},
+						Guard: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "y", Line: 1},
+							Value: "y",
+						},
+						Body: ast.NilLiteral{},
+					},
+				},
+			},
+		},
+		{"parseSpawnExpr", args{0, "spawn c.send(1)"},
+			ast.SpawnExpr{
+				Token: lexer.Token{Type: lexer.SPAWN, Lexeme: "spawn", Line: 1},
+				Call: ast.CallExpr{
+					Token: lexer.Token{Type: lexer.LEFT_PAREN, Lexeme: "(", Line: 1},
+					Callee: ast.GetExpr{
+						Token: lexer.Token{Type: lexer.DOT, Lexeme: ".", Line: 1},
+						Object: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "c", Line: 1},
+							Value: "c",
+						},
+						Name: ast.Identifier{
+							Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "send", Line: 1},
+							Value: "send",
+						},
+					},
+					Args: []ast.Node{
+						ast.NumLiteral{Token: lexer.Token{Type: lexer.NUMBER, Lexeme: "1", Literal: "1.0", Line: 1}, Value: 1.},
+					},
+				},
+			},
+		},
+		{"parseSpawnWithoutCall", args{0, "spawn f"}, nil},
+		{"parseKeywordProperty", args{0, "re.match"},
+			ast.GetExpr{
+				Token: lexer.Token{Type: lexer.DOT, Lexeme: ".", Line: 1},
+				Object: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "re", Line: 1},
+					Value: "re",
+				},
+				Name: ast.Identifier{
+					Token: lexer.Token{Type: lexer.IDENTIFIER, Lexeme: "match", Line: 1},
+					Value: "match",
+				},
+			},
+		},
+		{"parseNumberProperty", args{0, "a.1"}, nil},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -145,6 +395,33 @@ func TestParser_Parse(t *testing.T) {
 	}
 }
 
+func TestParser_Warnings(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileContent string
+		want        int
+	}{
+		{"matchReachable", "match x { 1 => 1, y if y => 2, 2 | 3 => 3, _ => 4 }", 0},
+		{"matchAfterWildcard", "match x { _ => 1, 2 => 2 }", 1},
+		{"matchAfterBinding", "match x { y => 1, _ => 2 }", 1},
+		{"matchCoveredLiterals", "match x { 1 => 1, 2 => 2, 2 | 1 => 3 }", 1},
+		{"matchListPatterns", "match x { [] => 1, [1] | [2] => 2, 1 | [_] => 3, [a, ...b] => 4 }", 0},
+		{"matchAfterListBinding", "match x { [a, ...b] => 1, [_] => 2, y => 3, [] => 4 }", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewParser(lexer.NewLexer([]byte(tt.fileContent)))
+			p.ParseExpr(LOWEST)
+			if len(p.Errors) > 0 {
+				t.Fatalf("ParseExpr() errors = %v", p.Errors)
+			}
+			if len(p.Warnings) != tt.want {
+				t.Errorf("Warnings = %v, want %d", p.Warnings, tt.want)
+			}
+		})
+	}
+}
+
 func prepareTmpFile(t *testing.T, content string) ([]byte, *os.File) {
 	f, err := os.CreateTemp("/tmp", "content")
 	if err != nil {
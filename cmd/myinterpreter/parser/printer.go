@@ -35,10 +35,22 @@ func (v *ASTPrinter) VisitNum(n ast.NumLiteral) interface{} {
 	v.write(n.String())
 	return nil
 }
+func (v *ASTPrinter) VisitBigInt(n ast.BigIntLiteral) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitDecimal(n ast.DecimalLiteral) interface{} {
+	v.write(n.String())
+	return nil
+}
 func (v *ASTPrinter) VisitString(n ast.StringLiteral) interface{} {
 	v.write(n.String())
 	return nil
 }
+func (v *ASTPrinter) VisitInterpolationExpr(n ast.InterpolationExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
 func (v *ASTPrinter) VisitGroupedExpr(n ast.GroupedExpr) interface{} {
 	v.write(n.String())
 	return nil
@@ -51,6 +63,38 @@ func (v *ASTPrinter) VisitInfixExpr(n ast.InfixExpr) interface{} {
 	v.write(n.String())
 	return nil
 }
+func (v *ASTPrinter) VisitIdentifier(n ast.Identifier) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitCallExpr(n ast.CallExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitFunctionLiteral(n ast.FunctionLiteral) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitCompoundAssignExpr(n ast.CompoundAssignExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitUpdateExpr(n ast.UpdateExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitMatchExpr(n ast.MatchExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitGetExpr(n ast.GetExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
+func (v *ASTPrinter) VisitSpawnExpr(n ast.SpawnExpr) interface{} {
+	v.write(n.String())
+	return nil
+}
 
 func (v *ASTPrinter) write(s string) {
 	if v.err != nil {
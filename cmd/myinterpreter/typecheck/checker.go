@@ -0,0 +1,448 @@
+package typecheck
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/ast"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/eval"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
+)
+
+type Type interface {
+	String() string
+}
+
+// Basic is a type without structure. Any is compatible with every type and
+// is inferred wherever the checker cannot tell what a value will be.
+type Basic string
+
+const (
+	Any      Basic = "Any"
+	Number   Basic = "Number"
+	String   Basic = "String"
+	Bool     Basic = "Bool"
+	Nil      Basic = "Nil"
+	BigInt   Basic = "BigInt"
+	Decimal  Basic = "Decimal"
+	Function Basic = "Function"
+	Channel  Basic = "Channel"
+	List     Basic = "List"
+)
+
+// numeric is the parameter type of natives that accept any kind of number.
+// It cannot be written in an annotation.
+const numeric Basic = "Numeric"
+
+func (t Basic) String() string { return string(t) }
+
+// Func is the type of a function whose signature is known. Params holds the
+// element type of a rest parameter last if Variadic is set.
+type Func struct {
+	Params   []Type
+	Names    []string // parameter names, used to check named arguments
+	Required int
+	Variadic bool
+	Return   Type
+}
+
+func (t Func) String() string {
+	params := make([]string, 0, len(t.Params))
+	for i, param := range t.Params {
+		switch {
+		case t.Variadic && i == len(t.Params)-1:
+			params = append(params, "..."+param.String())
+		case i >= t.Required:
+			params = append(params, param.String()+"?")
+		default:
+			params = append(params, param.String())
+		}
+	}
+
+	return fmt.Sprintf("(%s) => %s", strings.Join(params, ", "), t.Return)
+}
+
+func (t Func) maxArgs() int {
+	if t.Variadic {
+		return -1
+	}
+
+	return len(t.Params)
+}
+
+// param returns the type of the i-th positional argument.
+func (t Func) param(i int) Type {
+	if t.Variadic && i >= len(t.Params)-1 {
+		return t.Params[len(t.Params)-1]
+	}
+
+	return t.Params[i]
+}
+
+var basicTypes = map[string]Basic{
+	"Any":      Any,
+	"Number":   Number,
+	"String":   String,
+	"Bool":     Bool,
+	"Nil":      Nil,
+	"BigInt":   BigInt,
+	"Decimal":  Decimal,
+	"Function": Function,
+	"Channel":  Channel,
+	"List":     List,
+}
+
+// builtins are the signatures of the natives defined by the evaluator. The
+// names and arity must match their Parameters(); numbers are taken as
+// numeric, like the evaluator's numberArg and intArg do.
+var builtins = map[string]Type{
+	"bigint":  Func{Params: []Type{Any}, Names: []string{"value"}, Required: 1, Return: BigInt},
+	"decimal": Func{Params: []Type{Any}, Names: []string{"value"}, Required: 1, Return: Decimal},
+	"number":  Func{Params: []Type{Any}, Names: []string{"value"}, Required: 1, Return: Number},
+	"Channel": Func{Params: []Type{numeric}, Names: []string{"capacity"}, Return: Channel},
+	"regex":   Func{Params: []Type{String}, Names: []string{"pattern"}, Required: 1, Return: Any},
+	"clock":   Func{Return: Number},
+	"now":     Func{Return: Any},
+
+	"readFile":   Func{Params: []Type{String}, Names: []string{"path"}, Required: 1, Return: String},
+	"writeFile":  Func{Params: []Type{String, String}, Names: []string{"path", "content"}, Required: 2, Return: Nil},
+	"appendFile": Func{Params: []Type{String, String}, Names: []string{"path", "content"}, Required: 2, Return: Nil},
+	"listDir":    Func{Params: []Type{String}, Names: []string{"path"}, Return: List},
+	"exists":     Func{Params: []Type{String}, Names: []string{"path"}, Required: 1, Return: Bool},
+	"Reader":     Func{Params: []Type{String}, Names: []string{"path"}, Required: 1, Return: Any},
+}
+
+type scope struct {
+	types     map[string]Type
+	enclosing *scope
+}
+
+func (s *scope) lookup(name string) Type {
+	for curr := s; curr != nil; curr = curr.enclosing {
+		if t, ok := curr.types[name]; ok {
+			return t
+		}
+	}
+
+	if t, ok := builtins[name]; ok {
+		return t
+	}
+
+	return Any
+}
+
+// Checker infers the type of an expression before it is evaluated and
+// reports operations that are bound to fail at runtime. The checks mirror
+// the ones eval.Evaluator performs, with the same messages.
+type Checker struct {
+	Errors []error
+	scope  *scope
+}
+
+func NewChecker() *Checker {
+	return &Checker{
+		scope: &scope{types: make(map[string]Type)},
+	}
+}
+
+func (c *Checker) Check(tree ast.Node) Type {
+	return c.check(tree)
+}
+
+func (c *Checker) check(n ast.Node) Type {
+	if t, ok := n.Accept(c).(Type); ok {
+		return t
+	}
+
+	return Any
+}
+func (c *Checker) error(tok lexer.Token, err error) {
+	c.Errors = append(c.Errors, fmt.Errorf("[line %d] Error at '%s': %w", tok.Line, tok.Lexeme, err))
+}
+func (c *Checker) withScope(types map[string]Type, fn func()) {
+	prev := c.scope
+	c.scope = &scope{types: types, enclosing: prev}
+	defer func() { c.scope = prev }()
+
+	fn()
+}
+func (c *Checker) resolve(annotation *ast.TypeAnnotation) Type {
+	if annotation == nil {
+		return Any
+	}
+
+	if t, ok := basicTypes[annotation.Name]; ok {
+		return t
+	}
+	c.error(annotation.Token, fmt.Errorf("Unknown type '%s'.", annotation.Name))
+
+	return Any
+}
+
+func (c *Checker) VisitBoolean(_ ast.BooleanLiteral) interface{}  { return Bool }
+func (c *Checker) VisitNil(_ ast.NilLiteral) interface{}          { return Nil }
+func (c *Checker) VisitNum(_ ast.NumLiteral) interface{}          { return Number }
+func (c *Checker) VisitBigInt(_ ast.BigIntLiteral) interface{}    { return BigInt }
+func (c *Checker) VisitDecimal(_ ast.DecimalLiteral) interface{}  { return Decimal }
+func (c *Checker) VisitString(_ ast.StringLiteral) interface{}    { return String }
+func (c *Checker) VisitIdentifier(n ast.Identifier) interface{}   { return c.scope.lookup(n.Value) }
+func (c *Checker) VisitGroupedExpr(n ast.GroupedExpr) interface{} { return c.check(n.Value) }
+func (c *Checker) VisitGetExpr(n ast.GetExpr) interface{} {
+	c.check(n.Object)
+	return Any
+}
+func (c *Checker) VisitInterpolationExpr(n ast.InterpolationExpr) interface{} {
+	for _, part := range n.Parts {
+		c.check(part)
+	}
+
+	return String
+}
+func (c *Checker) VisitPrefixExpr(n ast.PrefixExpr) interface{} {
+	right := c.check(n.Right)
+
+	switch n.Op {
+	case "-":
+		if right == Any || isNumeric(right) {
+			return right
+		}
+		c.error(n.Token, eval.ErrOperandNumber)
+	case "!":
+		return Bool
+	}
+
+	return Any
+}
+func (c *Checker) VisitInfixExpr(n ast.InfixExpr) interface{} {
+	return c.infix(n.Token, n.Op, c.check(n.Left), c.check(n.Right))
+}
+func (c *Checker) infix(tok lexer.Token, op string, left, right Type) Type {
+	switch op {
+	case "==", "!=":
+		return Bool
+	case "+":
+		if left == String && right == String {
+			return String
+		}
+		if (left == Any || left == String) && (right == Any || right == String) {
+			return Any
+		}
+		if !compatibleNumbers(left, right) {
+			c.error(tok, eval.ErrOperandsNumbersOrStrings)
+			return Any
+		}
+		return arithmetic(left, right)
+	case "-", "*", "/":
+		if !compatibleNumbers(left, right) {
+			c.error(tok, eval.ErrOperandsNumbers)
+			return Any
+		}
+		return arithmetic(left, right)
+	case "<", "<=", ">", ">=":
+		if !compatibleNumbers(left, right) {
+			c.error(tok, eval.ErrOperandsNumbers)
+		}
+		return Bool
+	}
+
+	return Any
+}
+func (c *Checker) VisitCompoundAssignExpr(n ast.CompoundAssignExpr) interface{} {
+	return c.infix(n.Token, n.Op, c.check(n.Target), c.check(n.Value))
+}
+func (c *Checker) VisitUpdateExpr(n ast.UpdateExpr) interface{} {
+	return c.infix(n.Token, n.Op[:1], c.check(n.Target), Number)
+}
+func (c *Checker) VisitCallExpr(n ast.CallExpr) interface{} {
+	return c.call(n)
+}
+func (c *Checker) VisitSpawnExpr(n ast.SpawnExpr) interface{} {
+	c.call(n.Call)
+	return Nil
+}
+func (c *Checker) call(n ast.CallExpr) Type {
+	callee := c.check(n.Callee)
+
+	args := make([]Type, 0, len(n.Args))
+	for _, arg := range n.Args {
+		args = append(args, c.check(arg))
+	}
+
+	switch callee := callee.(type) {
+	case Func:
+		c.bindArgs(n, callee, args)
+		return callee.Return
+	case Basic:
+		if callee != Any && callee != Function {
+			c.error(n.Token, eval.ErrNotCallable)
+		}
+	}
+
+	for _, arg := range n.NamedArgs {
+		c.check(arg.Value)
+	}
+
+	return Any
+}
+func (c *Checker) bindArgs(n ast.CallExpr, fn Func, args []Type) {
+	max := fn.maxArgs()
+	if max >= 0 && len(args) > max || len(args) < fn.Required && len(n.NamedArgs) == 0 {
+		c.error(n.Token, eval.ArityError(fn.Required, max, len(args)))
+		return
+	}
+
+	for i, arg := range args {
+		if want := fn.param(i); !assignable(want, arg) {
+			c.error(n.Token, fmt.Errorf("Expected argument of type %s but got %s.", want, arg))
+		}
+	}
+
+	for _, arg := range n.NamedArgs {
+		got := c.check(arg.Value)
+
+		i := slices.Index(fn.Names, arg.Name.Value)
+		if i < 0 || fn.Variadic && i == len(fn.Params)-1 {
+			c.error(arg.Name.Token, fmt.Errorf("Unknown parameter '%s'.", arg.Name.Value))
+			continue
+		}
+
+		if want := fn.Params[i]; !assignable(want, got) {
+			c.error(arg.Name.Token, fmt.Errorf("Expected argument of type %s but got %s.", want, got))
+		}
+	}
+}
+func (c *Checker) VisitFunctionLiteral(n ast.FunctionLiteral) interface{} {
+	fn := Func{Return: c.resolve(n.ReturnType)}
+
+	var body Type
+	c.withScope(make(map[string]Type), func() {
+		for _, param := range n.Params {
+			t := c.resolve(param.Type)
+			fn.Params = append(fn.Params, t)
+			fn.Names = append(fn.Names, param.Name.Value)
+
+			switch {
+			case param.Rest:
+				fn.Variadic = true
+				c.scope.types[param.Name.Value] = List
+				continue
+			case param.Default != nil:
+				// defaults can refer to the parameters before them
+				if got := c.check(param.Default); !assignable(t, got) {
+					c.error(param.Name.Token, fmt.Errorf("Expected default value of type %s but got %s.", t, got))
+				}
+			default:
+				fn.Required++
+			}
+			c.scope.types[param.Name.Value] = t
+		}
+
+		body = c.check(n.Body)
+	})
+
+	if n.ReturnType == nil {
+		fn.Return = body
+	} else if !assignable(fn.Return, body) {
+		c.error(n.ReturnType.Token, fmt.Errorf("Expected return type %s but got %s.", fn.Return, body))
+	}
+
+	return fn
+}
+func (c *Checker) VisitMatchExpr(n ast.MatchExpr) interface{} {
+	subject := c.check(n.Subject)
+
+	var result Type
+	for _, arm := range n.Arms {
+		types := make(map[string]Type)
+		c.pattern(arm.Pattern, subject, types)
+
+		c.withScope(types, func() {
+			if arm.Guard != nil {
+				c.check(arm.Guard)
+			}
+
+			body := c.check(arm.Body)
+			if result == nil {
+				result = body
+			} else if result.String() != body.String() {
+				result = Any
+			}
+		})
+	}
+
+	if result == nil {
+		return Any
+	}
+
+	return result
+}
+func (c *Checker) pattern(pattern ast.Pattern, subject Type, types map[string]Type) {
+	switch pattern := pattern.(type) {
+	case ast.BindingPattern:
+		types[pattern.Name.Value] = subject
+	case ast.LiteralPattern:
+		c.check(pattern.Value)
+	case ast.AlternativePattern:
+		for _, alt := range pattern.Alternatives {
+			c.pattern(alt, subject, types)
+		}
+	case ast.ListPattern:
+		// lists do not track the type of their elements
+		for _, elem := range pattern.Elements {
+			c.pattern(elem, Any, types)
+		}
+		if pattern.Rest != nil {
+			c.pattern(pattern.Rest, List, types)
+		}
+	}
+}
+
+func isNumeric(t Type) bool {
+	return t == Number || t == BigInt || t == Decimal
+}
+
+// compatibleNumbers reports whether both operands may be numbers.
+func compatibleNumbers(left, right Type) bool {
+	return (left == Any || isNumeric(left)) && (right == Any || isNumeric(right))
+}
+
+// arithmetic returns the type of an arithmetic operation the same way the
+// evaluator promotes operands. Numbers mixed with big integers may become
+// either integers or decimals depending on their value.
+func arithmetic(left, right Type) Type {
+	switch {
+	case left == Any || right == Any:
+		return Any
+	case left == right:
+		return left
+	case left == Decimal || right == Decimal:
+		return Decimal
+	}
+
+	return Any
+}
+
+func assignable(want, got Type) bool {
+	if want == Any || got == Any {
+		return true
+	}
+
+	if _, ok := got.(Func); ok && want == Function {
+		return true
+	}
+	if want == numeric {
+		return isNumeric(got)
+	}
+
+	return want.String() == got.String()
+}
+
+func CheckErrors(errs []error) int {
+	for _, err := range errs {
+		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+
+	return 65
+}
@@ -0,0 +1,108 @@
+package typecheck
+
+import (
+	"testing"
+
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/eval"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/lexer"
+	"github.com/codecrafters-io/interpreter-starter-go/cmd/myinterpreter/parser"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{"inferLiteral", "1 + 2", "Number", ""},
+		{"inferConcat", "\"a\" + \"b\"", "String", ""},
+		{"inferExact", "1n + 2.5d", "Decimal", ""},
+		{"inferComparison", "1 < 2", "Bool", ""},
+		{"inferFunction", "(a: Number, b: Number) => a - b", "(Number, Number) => Number", ""},
+		{"inferPartialFunction", "(a: Number, b) => a - b", "(Number, Any) => Any", ""},
+		{"inferOptionalParams", "(a: Number, b: String = \"x\", ...rest) => a", "(Number, String?, ...Any) => Number", ""},
+		{"inferCall", "((s: String) => s + \"!\")(\"hi\")", "String", ""},
+		{"inferMatch", "match 1 { 1 => \"a\", n => \"b ${n}\" }", "String", ""},
+		{"nativeBigIntArgument", "Channel(2n)", "Channel", ""},
+		{"nativeDecimalArgument", "Channel(capacity: 1.0d)", "Channel", ""},
+		{"validProgram", "((c: Channel, n: Number): String => \"${spawn ((x: Number) => c.send(x * 2))(n)}${c.recv()}\")(Channel(), \"abc\".length + 18)", "String", ""},
+		{"unannotatedIsAny", "((a) => a - 1)(\"x\")", "Any", ""},
+		{"operandsNumbers", "\"a\" - 1", "", "[line 1] Error at '-': Operands must be numbers."},
+		{"operandsNumbersOrStrings", "true + 1", "", "[line 1] Error at '+': Operands must be two numbers or two strings."},
+		{"operandNumber", "-\"a\"", "", "[line 1] Error at '-': Operand must be a number."},
+		{"notCallable", "1(2)", "", "[line 1] Error at '(': Can only call functions and classes."},
+		{"arity", "((a: Number) => a)(1, 2)", "", "[line 1] Error at '(': Expected 1 arguments but got 2."},
+		{"arityRange", "((a, b = 1) => a)(1, 2, 3)", "", "[line 1] Error at '(': Expected 1 to 2 arguments but got 3."},
+		{"namedArgumentType", "((a: Number, b: String = \"x\") => a)(1, b: 2)", "", "[line 1] Error at 'b': Expected argument of type String but got Number."},
+		{"unknownNamedArg", "((a) => a)(c: 1)", "", "[line 1] Error at 'c': Unknown parameter 'c'."},
+		{"defaultType", "(a: Number = \"x\") => a", "", "[line 1] Error at 'a': Expected default value of type Number but got String."},
+		{"nativeArgumentType", "writeFile(\"a.txt\", 1)", "", "[line 1] Error at '(': Expected argument of type String but got Number."},
+		{"nativeNumericArgument", "Channel(\"1\")", "", "[line 1] Error at '(': Expected argument of type Numeric but got String."},
+		{"argumentType", "((a: Number) => a)(\"x\")", "", "[line 1] Error at '(': Expected argument of type Number but got String."},
+		{"returnType", "(a: Number): String => a * 2", "", "[line 1] Error at 'String': Expected return type String but got Number."},
+		{"annotatedParam", "(s: String) => s -= 1", "", "[line 1] Error at '-=': Operands must be numbers."},
+		{"unknownType", "(x: Foo) => x", "", "[line 1] Error at 'Foo': Unknown type 'Foo'."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer([]byte(tt.input)))
+			tree := p.ParseExpr(parser.LOWEST)
+			if len(p.Errors) > 0 {
+				t.Fatalf("ParseExpr() errors = %v", p.Errors)
+			}
+
+			c := NewChecker()
+			got := c.Check(tree)
+
+			if tt.wantErr != "" {
+				if len(c.Errors) == 0 || c.Errors[0].Error() != tt.wantErr {
+					t.Errorf("Check() errors = %v, want %v", c.Errors, tt.wantErr)
+				}
+				return
+			}
+
+			if len(c.Errors) > 0 {
+				t.Fatalf("Check() errors = %v", c.Errors)
+			}
+			if got.String() != tt.want {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBuiltins checks the signatures against the natives the evaluator
+// defines, so the two lists cannot drift apart.
+func TestBuiltins(t *testing.T) {
+	e := eval.NewEvaluator(eval.WithFileSystem(t.TempDir()))
+	for name, typ := range builtins {
+		t.Run(name, func(t *testing.T) {
+			p := parser.NewParser(lexer.NewLexer([]byte(name)))
+			native, ok := e.Eval(p.ParseExpr(parser.LOWEST)).(*eval.NativeFunction)
+			if !ok {
+				t.Fatalf("%s is not a native function", name)
+			}
+
+			fn := typ.(Func)
+			if len(fn.Params) != len(native.Params) {
+				t.Fatalf("%s has %d parameters, want %d", name, len(fn.Params), len(native.Params))
+			}
+			required := 0
+			for i, param := range native.Params {
+				if param.Name != fn.Names[i] {
+					t.Errorf("parameter %d of %s is named %s, want %s", i, name, fn.Names[i], param.Name)
+				}
+				if param.Rest != (fn.Variadic && i == len(fn.Params)-1) {
+					t.Errorf("parameter %d of %s has Rest = %v, want %v", i, name, !param.Rest, param.Rest)
+				}
+				if !param.Optional && !param.Rest {
+					required++
+				}
+			}
+			if fn.Required != required {
+				t.Errorf("%s requires %d arguments, want %d", name, fn.Required, required)
+			}
+		})
+	}
+}